@@ -0,0 +1,238 @@
+package buildinfo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// BuildPromotionCommand promotes a build according to a stage defined in the 'promotion' section of the project config file.
+// The command validates the published build-info against the stage rules, plans the promotion and applies it.
+type BuildPromotionCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	configFilePath     string
+	stageName          string
+	dryRun             bool
+	plan               *services.PromotionParams
+}
+
+func NewBuildPromotionCommand() *BuildPromotionCommand {
+	return &BuildPromotionCommand{}
+}
+
+func (bpc *BuildPromotionCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildPromotionCommand {
+	bpc.serverDetails = serverDetails
+	return bpc
+}
+
+func (bpc *BuildPromotionCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildPromotionCommand {
+	bpc.buildConfiguration = buildConfiguration
+	return bpc
+}
+
+// Set the path of the project config file containing the 'promotion' section.
+// If not set, the 'build.yaml' project config file is used.
+func (bpc *BuildPromotionCommand) SetConfigFilePath(configFilePath string) *BuildPromotionCommand {
+	bpc.configFilePath = configFilePath
+	return bpc
+}
+
+func (bpc *BuildPromotionCommand) SetStageName(stageName string) *BuildPromotionCommand {
+	bpc.stageName = stageName
+	return bpc
+}
+
+func (bpc *BuildPromotionCommand) SetDryRun(dryRun bool) *BuildPromotionCommand {
+	bpc.dryRun = dryRun
+	return bpc
+}
+
+// Returns the promotion planned by the last run of the command.
+func (bpc *BuildPromotionCommand) Plan() *services.PromotionParams {
+	return bpc.plan
+}
+
+func (bpc *BuildPromotionCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bpc.serverDetails, nil
+}
+
+func (bpc *BuildPromotionCommand) CommandName() string {
+	return "rt_build_promote_stage"
+}
+
+func (bpc *BuildPromotionCommand) Run() (err error) {
+	promotionConfig, err := bpc.readPromotionConfig()
+	if err != nil {
+		return err
+	}
+	stage, err := promotionConfig.GetStage(bpc.stageName)
+	if err != nil {
+		return err
+	}
+	// The explicit server details take precedence over the server of the promotion config, which resolves them accordingly
+	bpc.serverDetails = promotionConfig.ServerDetails()
+	buildName, err := bpc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bpc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	if buildName == "" || buildNumber == "" {
+		return errorutils.CheckErrorf("the build name and build number are mandatory for promoting a build")
+	}
+	projectKey := bpc.buildConfiguration.GetProject()
+	servicesManager, err := utils.CreateServiceManager(bpc.serverDetails, -1, 0, bpc.dryRun)
+	if err != nil {
+		return err
+	}
+	if err = ValidateBuild(servicesManager, buildName, buildNumber, projectKey, stage); err != nil {
+		return err
+	}
+	bpc.plan = PlanPromotion(buildName, buildNumber, projectKey, stage)
+	logPromotionPlan(stage, bpc.plan, bpc.dryRun)
+	return servicesManager.PromoteBuild(*bpc.plan)
+}
+
+func (bpc *BuildPromotionCommand) readPromotionConfig() (*project.PromotionConfig, error) {
+	configFilePath := bpc.configFilePath
+	if configFilePath == "" {
+		var exists bool
+		var err error
+		configFilePath, exists, err = project.GetProjectConfFilePath(project.Build)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errorutils.CheckErrorf("the build project configuration does not exist. Please run 'jf build-config' and add a %s section to the generated file", project.ProjectConfigPromotion)
+		}
+	}
+	return project.ReadPromotionConfiguration(configFilePath, bpc.serverDetails)
+}
+
+// Validate the published build-info against the rules of the promotion stage.
+// The locally collected build-info is validated instead, only if the published build-info can't be read.
+func ValidateBuild(servicesManager artifactory.ArtifactoryServicesManager, buildName, buildNumber, projectKey string, stage *project.PromotionStage) error {
+	params := services.NewBuildInfoParams()
+	params.BuildName, params.BuildNumber, params.ProjectKey = buildName, buildNumber, projectKey
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(params)
+	if err != nil || !found {
+		if err != nil {
+			log.Debug("Failed to read the published build-info:", err.Error())
+		}
+		log.Info(fmt.Sprintf("The build %s/%s couldn't be read from Artifactory. Validating the locally collected build-info instead.", buildName, buildNumber))
+		return ValidateLocalBuild(buildName, buildNumber, projectKey, stage)
+	}
+	var artifacts []buildInfo.Artifact
+	for _, module := range publishedBuildInfo.BuildInfo.Modules {
+		artifacts = append(artifacts, module.Artifacts...)
+	}
+	return validateBuildAgainstStage(buildName, buildNumber, stage, publishedBuildInfo.BuildInfo.Properties, artifacts)
+}
+
+// Validate the locally collected build-info against the rules of the promotion stage:
+// 1. The build-info was collected locally.
+// 2. All the required properties were collected.
+// 3. If the stage has a source repository, all the build artifacts were deployed to it.
+func ValidateLocalBuild(buildName, buildNumber, projectKey string, stage *project.PromotionStage) error {
+	if _, err := build.ReadBuildInfoGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	partials, err := build.ReadPartialBuildInfoFiles(buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	properties := buildInfo.Env{}
+	var artifacts []buildInfo.Artifact
+	for _, partial := range partials {
+		for key, value := range partial.Env {
+			properties[key] = value
+		}
+		artifacts = append(artifacts, partial.Artifacts...)
+	}
+	return validateBuildAgainstStage(buildName, buildNumber, stage, properties, artifacts)
+}
+
+// Validate the properties and the artifacts of a build against the rules of the promotion stage.
+func validateBuildAgainstStage(buildName, buildNumber string, stage *project.PromotionStage, properties buildInfo.Env, artifacts []buildInfo.Artifact) error {
+	if missing := getMissingRequiredProperties(properties, stage.RequiredProperties); len(missing) > 0 {
+		return errorutils.CheckErrorf("the build %s/%s cannot be promoted to the '%s' stage. The following required properties were not collected: %s",
+			buildName, buildNumber, stage.Name, strings.Join(missing, ", "))
+	}
+	if stage.SourceRepo == "" {
+		return nil
+	}
+	var mismatched []string
+	for _, artifact := range artifacts {
+		if artifact.OriginalDeploymentRepo != "" && artifact.OriginalDeploymentRepo != stage.SourceRepo {
+			mismatched = append(mismatched, artifact.OriginalDeploymentRepo+"/"+artifact.Path)
+		}
+	}
+	if len(mismatched) > 0 {
+		return errorutils.CheckErrorf("the build %s/%s cannot be promoted to the '%s' stage. The following artifacts were not deployed to the '%s' source repository: %s",
+			buildName, buildNumber, stage.Name, stage.SourceRepo, strings.Join(mismatched, ", "))
+	}
+	return nil
+}
+
+func getMissingRequiredProperties(properties buildInfo.Env, requiredProperties []string) (missing []string) {
+	for _, required := range requiredProperties {
+		if _, collected := properties[required]; !collected {
+			missing = append(missing, required)
+		}
+	}
+	sort.Strings(missing)
+	return
+}
+
+// Create the promotion parameters for the given build, according to the stage rules.
+func PlanPromotion(buildName, buildNumber, projectKey string, stage *project.PromotionStage) *services.PromotionParams {
+	params := services.NewPromotionParams()
+	params.BuildName = buildName
+	params.BuildNumber = buildNumber
+	params.ProjectKey = projectKey
+	params.SourceRepo = stage.SourceRepo
+	params.TargetRepo = stage.TargetRepo
+	params.Status = stage.Status
+	params.Comment = stage.Comment
+	params.Copy = stage.Copy
+	params.IncludeDependencies = stage.IncludeDependencies
+	params.Properties = stage.Properties
+	params.FailFast = true
+	return &params
+}
+
+func logPromotionPlan(stage *project.PromotionStage, plan *services.PromotionParams, dryRun bool) {
+	action := "Moving"
+	if plan.Copy {
+		action = "Copying"
+	}
+	prefix := ""
+	if dryRun {
+		prefix = "[Dry run] "
+	}
+	source := plan.SourceRepo
+	if source == "" {
+		source = "the build repositories"
+	}
+	log.Info(fmt.Sprintf("%sPromotion plan for stage '%s': %s the artifacts of build %s/%s from %s to %s.",
+		prefix, stage.Name, action, plan.BuildName, plan.BuildNumber, source, plan.TargetRepo))
+	if plan.Status != "" {
+		log.Info(fmt.Sprintf("%sThe build status will be set to '%s'.", prefix, plan.Status))
+	}
+	if plan.Properties != "" {
+		log.Info(fmt.Sprintf("%sThe following properties will be set on the promoted artifacts: %s", prefix, plan.Properties))
+	}
+}
@@ -0,0 +1,157 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	commonTests "github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+)
+
+var stagingStage = &project.PromotionStage{
+	Name:               "staging",
+	SourceRepo:         "libs-dev-local",
+	TargetRepo:         "libs-staging-local",
+	Status:             "staged",
+	Copy:               true,
+	RequiredProperties: []string{"buildInfo.env.TESTS_PASSED"},
+}
+
+func TestValidateLocalBuild(t *testing.T) {
+	cleanUpJfrogHome, err := tests.SetJfrogHome()
+	assert.NoError(t, err)
+	defer cleanUpJfrogHome()
+
+	buildName, buildNumber := "promote-test-"+strconv.FormatInt(time.Now().Unix(), 10), "1"
+	defer func() {
+		assert.NoError(t, build.RemoveBuildDir(buildName, buildNumber, ""))
+	}()
+
+	// No local build-info
+	assert.ErrorContains(t, ValidateLocalBuild(buildName, buildNumber, "", stagingStage), "no previous commands")
+
+	// Artifacts were collected, but the required property is missing
+	assert.NoError(t, build.SaveBuildGeneralDetails(buildName, buildNumber, ""))
+	assert.NoError(t, build.SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildInfo.Partial) {
+		partial.Artifacts = []buildInfo.Artifact{{Name: "a.jar", Path: "a/a.jar", OriginalDeploymentRepo: "libs-dev-local"}}
+	}))
+	assert.ErrorContains(t, ValidateLocalBuild(buildName, buildNumber, "", stagingStage), "buildInfo.env.TESTS_PASSED")
+
+	// The required property was collected
+	assert.NoError(t, build.SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildInfo.Partial) {
+		partial.Env = buildInfo.Env{"buildInfo.env.TESTS_PASSED": "true"}
+	}))
+	assert.NoError(t, ValidateLocalBuild(buildName, buildNumber, "", stagingStage))
+
+	// An artifact was deployed to a repository other than the source repository of the stage
+	assert.NoError(t, build.SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildInfo.Partial) {
+		partial.Artifacts = []buildInfo.Artifact{{Name: "b.jar", Path: "b/b.jar", OriginalDeploymentRepo: "other-local"}}
+	}))
+	assert.ErrorContains(t, ValidateLocalBuild(buildName, buildNumber, "", stagingStage), "other-local/b/b.jar")
+}
+
+func TestValidatePublishedBuild(t *testing.T) {
+	cleanUpJfrogHome, err := tests.SetJfrogHome()
+	assert.NoError(t, err)
+	defer cleanUpJfrogHome()
+
+	// The build isn't collected locally
+	buildName, buildNumber := "promote-published-"+strconv.FormatInt(time.Now().Unix(), 10), "1"
+	publishedBuildInfo := &buildInfo.PublishedBuildInfo{BuildInfo: buildInfo.BuildInfo{Name: buildName, Number: buildNumber}}
+	testServer, _, servicesManager := commonTests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/build/"+buildName+"/"+buildNumber {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(publishedBuildInfo)
+		assert.NoError(t, err)
+		_, err = w.Write(content)
+		assert.NoError(t, err)
+	})
+	defer testServer.Close()
+
+	// The required property is missing
+	publishedBuildInfo.BuildInfo.Modules = []buildInfo.Module{{Id: "a", Artifacts: []buildInfo.Artifact{{Name: "a.jar", Path: "a/a.jar", OriginalDeploymentRepo: "libs-dev-local"}}}}
+	assert.ErrorContains(t, ValidateBuild(servicesManager, buildName, buildNumber, "", stagingStage), "buildInfo.env.TESTS_PASSED")
+
+	// The required property was published
+	publishedBuildInfo.BuildInfo.Properties = buildInfo.Env{"buildInfo.env.TESTS_PASSED": "true"}
+	assert.NoError(t, ValidateBuild(servicesManager, buildName, buildNumber, "", stagingStage))
+
+	// An artifact was deployed to a repository other than the source repository of the stage
+	publishedBuildInfo.BuildInfo.Modules = append(publishedBuildInfo.BuildInfo.Modules, buildInfo.Module{Id: "b", Artifacts: []buildInfo.Artifact{{Name: "b.jar", Path: "b/b.jar", OriginalDeploymentRepo: "other-local"}}})
+	assert.ErrorContains(t, ValidateBuild(servicesManager, buildName, buildNumber, "", stagingStage), "other-local/b/b.jar")
+
+	// A build, which wasn't published, is validated against the local build-info
+	assert.ErrorContains(t, ValidateBuild(servicesManager, buildName, "2", "", stagingStage), "no previous commands")
+}
+
+func TestPlanPromotion(t *testing.T) {
+	plan := PlanPromotion("name", "1", "proj", stagingStage)
+	assert.Equal(t, "name", plan.BuildName)
+	assert.Equal(t, "1", plan.BuildNumber)
+	assert.Equal(t, "proj", plan.ProjectKey)
+	assert.Equal(t, "libs-dev-local", plan.SourceRepo)
+	assert.Equal(t, "libs-staging-local", plan.TargetRepo)
+	assert.Equal(t, "staged", plan.Status)
+	assert.True(t, plan.Copy)
+	assert.True(t, plan.FailFast)
+}
+
+func TestBuildPromotionCommandDryRun(t *testing.T) {
+	cleanUpJfrogHome, err := tests.SetJfrogHome()
+	assert.NoError(t, err)
+	defer cleanUpJfrogHome()
+
+	buildName, buildNumber := "promote-dry-run-"+strconv.FormatInt(time.Now().Unix(), 10), "2"
+	defer func() {
+		assert.NoError(t, build.RemoveBuildDir(buildName, buildNumber, ""))
+	}()
+	assert.NoError(t, build.SaveBuildGeneralDetails(buildName, buildNumber, ""))
+	assert.NoError(t, build.SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildInfo.Partial) {
+		partial.Artifacts = []buildInfo.Artifact{{Name: "a.jar", Path: "a/a.jar", OriginalDeploymentRepo: "libs-staging-local"}}
+	}))
+
+	var requestBody services.BuildPromotionBody
+	testServer, serverDetails, _ := commonTests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// The build wasn't published, so the local build-info is validated
+			assert.Equal(t, "/api/build/"+buildName+"/"+buildNumber, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "/api/build/promote/"+buildName+"/"+buildNumber, r.URL.Path)
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(content, &requestBody))
+		w.WriteHeader(http.StatusOK)
+	})
+	defer testServer.Close()
+
+	promotionCommand := NewBuildPromotionCommand().
+		SetServerDetails(serverDetails).
+		SetBuildConfiguration(build.NewBuildConfiguration(buildName, buildNumber, "", "")).
+		SetConfigFilePath(filepath.Join("..", "..", "..", "common", "project", "testdata", "build-promotion.yaml")).
+		SetStageName("release").
+		SetDryRun(true)
+	assert.NoError(t, promotionCommand.Run())
+
+	assert.Equal(t, "libs-release-local", promotionCommand.Plan().TargetRepo)
+	assert.Equal(t, "libs-staging-local", requestBody.SourceRepo)
+	assert.Equal(t, "libs-release-local", requestBody.TargetRepo)
+	assert.Equal(t, "released", requestBody.Status)
+	if assert.NotNil(t, requestBody.DryRun) {
+		assert.True(t, *requestBody.DryRun)
+	}
+	assert.Equal(t, []string{"true"}, requestBody.Properties["released"])
+}
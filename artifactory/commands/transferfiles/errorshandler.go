@@ -299,7 +299,12 @@ func getErrorsFiles(repoKeys []string, isRetry bool) (filesPaths []string, err e
 
 // Count the number of transfer failures of a given subset of repositories
 func getRetryErrorCount(repoKeys []string) (int, error) {
-	files, err := getErrorsFiles(repoKeys, true)
+	return getErrorsCount(repoKeys, true)
+}
+
+// Count the number of retryable or skipped errors of a given subset of repositories
+func getErrorsCount(repoKeys []string, isRetry bool) (int, error) {
+	files, err := getErrorsFiles(repoKeys, isRetry)
 	if err != nil {
 		return -1, err
 	}
@@ -19,6 +19,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferfiles/state"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils/precheckrunner"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/commandsummary"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	usageReporter "github.com/jfrog/jfrog-cli-core/v2/utils/usage"
@@ -679,6 +680,62 @@ func (tdc *TransferFilesCommand) cleanup(originalErr error, sourceRepos []string
 	if csvErrorsFile != "" {
 		log.Info(fmt.Sprintf("Errors occurred during the transfer. Check the errors summary CSV file in: %s", csvErrorsFile))
 	}
+
+	if commandsummary.ShouldRecordSummary() {
+		if e = tdc.recordCommandSummary(sourceRepos); e != nil {
+			log.Error("Couldn't record the transfer-files command summary", e)
+		}
+	}
+	return
+}
+
+// Record the per-repository results of the transfer in the command summary.
+func (tdc *TransferFilesCommand) recordCommandSummary(sourceRepos []string) error {
+	result := commandsummary.TransferFilesResult{
+		Started:              tdc.stateManager.GetStartTimestamp(),
+		TransferredFiles:     tdc.stateManager.OverallTransfer.TransferredUnits,
+		TransferredSizeBytes: tdc.stateManager.OverallTransfer.TransferredSizeBytes,
+		Failures:             tdc.stateManager.TransferFailures,
+		DelayedFiles:         tdc.stateManager.DelayedFiles,
+	}
+	for _, repoKey := range sourceRepos {
+		repoResult, exists, err := getRepoTransferResult(repoKey)
+		if err != nil {
+			return err
+		}
+		if exists {
+			result.Repositories = append(result.Repositories, repoResult)
+		}
+	}
+	transferSummary, err := commandsummary.NewTransferFilesSummary()
+	if err != nil {
+		return err
+	}
+	return transferSummary.Record(result)
+}
+
+// Get the transfer results of a repository from its state, and from its errors and delayed files.
+// Returns false if the repository wasn't transferred by this or by a previous run.
+func getRepoTransferResult(repoKey string) (repoResult commandsummary.TransferRepoResult, exists bool, err error) {
+	transferState, exists, err := state.LoadTransferState(repoKey, false)
+	if err != nil || !exists {
+		return
+	}
+	repoResult.Repository = repoKey
+	for _, phaseInfo := range []state.ProgressState{transferState.CurrentRepo.Phase1Info, transferState.CurrentRepo.Phase2Info, transferState.CurrentRepo.Phase3Info} {
+		repoResult.TransferredFiles += phaseInfo.TransferredUnits
+		repoResult.TransferredSizeBytes += phaseInfo.TransferredSizeBytes
+	}
+	retryableErrors, err := getErrorsCount([]string{repoKey}, true)
+	if err != nil {
+		return
+	}
+	skippedErrors, err := getErrorsCount([]string{repoKey}, false)
+	if err != nil {
+		return
+	}
+	repoResult.Failures = retryableErrors + skippedErrors
+	repoResult.DelayedFiles, err = getDelayedFilesCount([]string{repoKey})
 	return
 }
 
@@ -0,0 +1,287 @@
+package commandsummary
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The version of the summary archive format.
+	// Increase it on changes, which can't be read by previous versions.
+	summaryArchiveVersion      = 1
+	summaryArchiveManifestName = "summary-manifest.json"
+)
+
+// Data files named by the SHA1 of their recording arguments e.g. the scan results of a specific build.
+// Files recorded with the same arguments hold the same record, so only one of them is kept when merging.
+var sha1FileNamePattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// The manifest of a summary archive.
+// It holds the values, which affect the Markdown generation, so that the summary can be regenerated in a different environment.
+type SummaryArchiveManifest struct {
+	Version              int    `json:"version"`
+	PlatformUrl          string `json:"platformUrl,omitempty"`
+	PlatformMajorVersion int    `json:"platformMajorVersion,omitempty"`
+	ExtendedSummary      bool   `json:"extendedSummary,omitempty"`
+}
+
+// The summary implementations of the commands, by their command name.
+// Used to regenerate the summaries of merged archives.
+var summaryImplementations = map[string]func() CommandSummaryInterface{
+	"build-info":     func() CommandSummaryInterface { return &BuildInfoSummary{} },
+	"upload":         func() CommandSummaryInterface { return &UploadSummary{} },
+	"download":       func() CommandSummaryInterface { return &DownloadSummary{} },
+	"delete":         func() CommandSummaryInterface { return &DeleteSummary{} },
+	"transfer-files": func() CommandSummaryInterface { return &TransferFilesSummary{} },
+	"custom":         func() CommandSummaryInterface { return &CustomSummary{} },
+}
+
+// Register the summary implementation of a command, which isn't part of this module.
+// The summaries of registered commands are regenerated after merging summary archives.
+func RegisterSummaryImplementation(commandsName string, newImplementation func() CommandSummaryInterface) {
+	summaryImplementations[commandsName] = newImplementation
+}
+
+// Archive the command summary directory, located under outputDir, into a zip file.
+// The archive includes the recorded data files and a manifest, but not the generated output files, which are regenerated after merging.
+func CreateSummaryArchive(outputDir, archivePath string) (err error) {
+	summaryDir := filepath.Join(outputDir, OutputDirName)
+	exists, err := fileutils.IsDirExists(summaryDir, false)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errorutils.CheckErrorf("the command summary directory '%s' doesn't exist", summaryDir)
+	}
+	archiveFile, err := os.Create(archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(archiveFile.Close()))
+	}()
+	writer := zip.NewWriter(archiveFile)
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(writer.Close()))
+	}()
+
+	manifestContent, err := json.Marshal(SummaryArchiveManifest{
+		Version:              summaryArchiveVersion,
+		PlatformUrl:          StaticMarkdownConfig.GetPlatformUrl(),
+		PlatformMajorVersion: StaticMarkdownConfig.GetPlatformMajorVersion(),
+		ExtendedSummary:      StaticMarkdownConfig.IsExtendedSummary(),
+	})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = writeZipEntry(writer, summaryArchiveManifestName, manifestContent); err != nil {
+		return err
+	}
+	return errorutils.CheckError(filepath.WalkDir(summaryDir, func(filePath string, entry os.DirEntry, walkErr error) error {
+		if walkErr != nil || entry.IsDir() || isOutputFile(entry.Name()) {
+			return walkErr
+		}
+		relativePath, err := filepath.Rel(outputDir, filePath)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		// Zip entries are always separated by slashes
+		return writeZipEntry(writer, filepath.ToSlash(relativePath), content)
+	}))
+}
+
+func writeZipEntry(writer *zip.Writer, name string, content []byte) error {
+	entryWriter, err := writer.Create(name)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = entryWriter.Write(content)
+	return errorutils.CheckError(err)
+}
+
+// Merge summary archives, created by CreateSummaryArchive in several jobs, into the command summary directory under outputDir,
+// and regenerate the summaries of the merged commands.
+// Duplicate data files are kept once - files named by the SHA1 of their recording arguments are identified by their name,
+// and other data files are identified by the SHA1 of their content.
+func MergeSummaryArchives(outputDir string, archivePaths ...string) error {
+	var manifest *SummaryArchiveManifest
+	mergedCommands := make(map[string]bool)
+	for _, archivePath := range archivePaths {
+		log.Info("Merging the command summary archive", archivePath)
+		archiveManifest, commands, err := extractSummaryArchive(archivePath, outputDir)
+		if err != nil {
+			return err
+		}
+		if manifest == nil {
+			manifest = archiveManifest
+		} else if *manifest != *archiveManifest {
+			log.Warn("The summary archive", archivePath, "was created for a different JFrog Platform configuration. The configuration of the first archive is used.")
+		}
+		for _, command := range commands {
+			mergedCommands[command] = true
+		}
+	}
+	if manifest == nil {
+		return nil
+	}
+	StaticMarkdownConfig.setPlatformUrl(manifest.PlatformUrl)
+	StaticMarkdownConfig.setPlatformMajorVersion(manifest.PlatformMajorVersion)
+	StaticMarkdownConfig.setExtendedSummary(manifest.ExtendedSummary)
+	if err := regenerateSummaries(outputDir, mergedCommands); err != nil {
+		return err
+	}
+	return WriteFinalMarkdown(outputDir)
+}
+
+// Extract the data files of a summary archive into outputDir. Returns the archive manifest and the names of the archived commands.
+func extractSummaryArchive(archivePath, outputDir string) (manifest *SummaryArchiveManifest, commands []string, err error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	var dataFiles []*zip.File
+	for _, file := range reader.File {
+		if file.Name == summaryArchiveManifestName {
+			manifest = new(SummaryArchiveManifest)
+			if err = unmarshalZipEntry(file, manifest); err != nil {
+				return
+			}
+			continue
+		}
+		dataFiles = append(dataFiles, file)
+	}
+	if manifest == nil {
+		return nil, nil, errorutils.CheckErrorf("'%s' is not a command summary archive: %s is missing", archivePath, summaryArchiveManifestName)
+	}
+	if manifest.Version > summaryArchiveVersion {
+		return nil, nil, errorutils.CheckErrorf("the command summary archive '%s' was created by a newer version of JFrog CLI (archive version %d). Please upgrade JFrog CLI", archivePath, manifest.Version)
+	}
+	commandsSet := make(map[string]bool)
+	for _, file := range dataFiles {
+		// The entries are expected under jfrog-command-summary/<command>/. Cleaning the path also rejects entries outside the output directory.
+		entryPath := path.Clean(file.Name)
+		parts := strings.Split(entryPath, "/")
+		if len(parts) < 3 || parts[0] != OutputDirName || strings.HasSuffix(file.Name, "/") {
+			log.Debug("Skipping the unexpected summary archive entry", file.Name)
+			continue
+		}
+		var targetDir string
+		if targetDir, err = getArchiveEntryTargetDir(outputDir, file.Name); err != nil {
+			return
+		}
+		if err = extractDataFile(file, targetDir); err != nil {
+			return
+		}
+		commandsSet[parts[1]] = true
+	}
+	for command := range commandsSet {
+		commands = append(commands, command)
+	}
+	return
+}
+
+// Returns the directory to extract the archive entry to. Entries, which would be extracted outside the output directory, are rejected.
+func getArchiveEntryTargetDir(outputDir, entryName string) (string, error) {
+	// Zip entries are always separated by slashes, so backslashes may only be used to escape the output directory on Windows
+	if strings.Contains(entryName, "\\") || path.IsAbs(entryName) || filepath.IsAbs(entryName) || filepath.VolumeName(entryName) != "" {
+		return "", errorutils.CheckErrorf("invalid command summary archive entry '%s'", entryName)
+	}
+	cleanOutputDir := filepath.Clean(outputDir)
+	targetDir := filepath.Join(cleanOutputDir, filepath.FromSlash(path.Dir(path.Clean(entryName))))
+	if !strings.HasPrefix(targetDir, cleanOutputDir+string(os.PathSeparator)) {
+		return "", errorutils.CheckErrorf("invalid command summary archive entry '%s': the entry is outside the output directory", entryName)
+	}
+	return targetDir, nil
+}
+
+func extractDataFile(file *zip.File, targetDir string) error {
+	content, err := readZipEntry(file)
+	if err != nil {
+		return err
+	}
+	fileName := path.Base(file.Name)
+	switch {
+	case sha1FileNamePattern.MatchString(fileName):
+		// Files recorded with the same arguments are kept once
+	case strings.HasSuffix(fileName, ".sarif"):
+		fileName = fileNameToSha1(string(content)) + ".sarif"
+	default:
+		// Randomly named data files are identified by their content
+		fileName = fileNameToSha1(string(content)) + strings.TrimPrefix(DataFileFormat, "*")
+	}
+	if err = createDirIfNotExists(targetDir); err != nil {
+		return err
+	}
+	exists, err := fileutils.IsFileExists(filepath.Join(targetDir, fileName), false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.Debug("Skipping the duplicate summary data file", file.Name)
+		return nil
+	}
+	return createAndWriteToFile(targetDir, fileName, content)
+}
+
+func readZipEntry(file *zip.File) (content []byte, err error) {
+	entryReader, err := file.Open()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(entryReader.Close()))
+	}()
+	content, err = io.ReadAll(entryReader)
+	return content, errorutils.CheckError(err)
+}
+
+func unmarshalZipEntry(file *zip.File, target any) error {
+	content, err := readZipEntry(file)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(json.Unmarshal(content, target))
+}
+
+// Regenerate the summaries of the merged commands, which have a known summary implementation.
+func regenerateSummaries(outputDir string, commands map[string]bool) error {
+	sortedCommands := make([]string, 0, len(commands))
+	for command := range commands {
+		sortedCommands = append(sortedCommands, command)
+	}
+	sort.Strings(sortedCommands)
+	for _, command := range sortedCommands {
+		newImplementation, ok := summaryImplementations[command]
+		if !ok {
+			log.Debug("No summary implementation is registered for the", command, "command. Its summary is merged, but not regenerated.")
+			continue
+		}
+		cs, err := newCommandSummary(newImplementation(), command, outputDir)
+		if err != nil {
+			return err
+		}
+		if err = cs.GenerateMarkdown(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
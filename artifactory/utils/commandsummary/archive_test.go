@@ -0,0 +1,97 @@
+package commandsummary
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSummaryArchives(t *testing.T) {
+	tempDir := t.TempDir()
+	var archives []string
+	// Both jobs record the same deleted file and the same indexed scan results
+	for i, deletedPath := range []string{"generic-local/a.zip", "generic-local/b.zip"} {
+		jobDir := filepath.Join(tempDir, fmt.Sprintf("job%d", i))
+		t.Setenv(coreutils.SummaryOutputDirPathEnv, jobDir)
+		cs, err := NewDeleteSummary()
+		assert.NoError(t, err)
+		assert.NoError(t, cs.Record(DeleteResultsWrapper{Results: []DeleteResult{{Path: "generic-local/common.zip"}}}))
+		assert.NoError(t, cs.Record(DeleteResultsWrapper{Results: []DeleteResult{{Path: deletedPath}}}))
+		assert.NoError(t, cs.RecordWithIndex("scan results", BuildScan, "my-build", "1"))
+		assert.NoError(t, cs.GenerateMarkdown())
+
+		archivePath := filepath.Join(tempDir, fmt.Sprintf("summary%d.zip", i))
+		assert.NoError(t, CreateSummaryArchive(jobDir, archivePath))
+		archives = append(archives, archivePath)
+	}
+
+	mergedDir := filepath.Join(tempDir, "merged")
+	assert.NoError(t, MergeSummaryArchives(mergedDir, archives...))
+
+	deleteDir := filepath.Join(mergedDir, OutputDirName, "delete")
+	cs, err := newCommandSummary(&DeleteSummary{}, "delete", mergedDir)
+	assert.NoError(t, err)
+	dataFiles, err := cs.GetDataFilesPaths()
+	assert.NoError(t, err)
+	assert.Len(t, dataFiles, 3)
+	scanResults, err := os.ReadDir(filepath.Join(deleteDir, string(BuildScan)))
+	assert.NoError(t, err)
+	assert.Len(t, scanResults, 1)
+
+	markdown, err := os.ReadFile(filepath.Join(deleteDir, finalMarkdownFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(markdown), "<pre>📦 generic-local\n├── 📄 a.zip\n├── 📄 b.zip\n└── 📄 common.zip\n\n</pre>")
+
+	// The final Markdown of all the merged summaries is regenerated
+	finalMarkdown, err := os.ReadFile(filepath.Join(mergedDir, OutputDirName, finalMarkdownFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, string(markdown), string(finalMarkdown))
+
+	// Merging the same archive again changes nothing
+	assert.NoError(t, MergeSummaryArchives(mergedDir, archives[0]))
+	dataFiles, err = cs.GetDataFilesPaths()
+	assert.NoError(t, err)
+	assert.Len(t, dataFiles, 3)
+}
+
+func TestMergeInvalidSummaryArchive(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "invalid.zip")
+	assert.NoError(t, os.WriteFile(archivePath, []byte("not a zip"), 0600))
+	assert.Error(t, MergeSummaryArchives(t.TempDir(), archivePath))
+}
+
+func TestMergeSummaryArchiveWithInvalidEntries(t *testing.T) {
+	for _, entryName := range []string{
+		OutputDirName + `/delete/..\..\..\evil`,
+		OutputDirName + `\delete\..\evil`,
+	} {
+		t.Run(entryName, func(t *testing.T) {
+			archivePath := filepath.Join(t.TempDir(), "summary.zip")
+			archiveFile, err := os.Create(archivePath)
+			assert.NoError(t, err)
+			writer := zip.NewWriter(archiveFile)
+			assert.NoError(t, writeZipEntry(writer, summaryArchiveManifestName, []byte(`{"version":1}`)))
+			assert.NoError(t, writeZipEntry(writer, OutputDirName+"/delete/"+entryName, []byte("{}")))
+			assert.NoError(t, writer.Close())
+			assert.NoError(t, archiveFile.Close())
+			assert.ErrorContains(t, MergeSummaryArchives(t.TempDir(), archivePath), "invalid command summary archive entry")
+		})
+	}
+}
+
+func TestGetArchiveEntryTargetDir(t *testing.T) {
+	outputDir := t.TempDir()
+	targetDir, err := getArchiveEntryTargetDir(outputDir, OutputDirName+"/delete/data.json")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(outputDir, OutputDirName, "delete"), targetDir)
+
+	for _, entryName := range []string{"/" + OutputDirName + "/delete/data.json", OutputDirName + `\delete\data.json`, "../" + OutputDirName + "/data.json"} {
+		_, err = getArchiveEntryTargetDir(outputDir, entryName)
+		assert.ErrorContains(t, err, "invalid command summary archive entry", entryName)
+	}
+}
@@ -0,0 +1,188 @@
+package commandsummary
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// GitHub limits the size of a job summary to 1MB.
+	gitHubMarkdownSizeBudget = 1024 * 1024
+	// Set to true when running in GitHub Actions.
+	gitHubActionsEnv = "GITHUB_ACTIONS"
+	// The full Markdown of a truncated summary is saved to this file, next to the truncated Markdown.
+	fullMarkdownFileName = "markdown-full.md"
+)
+
+// Returns the maximum size in bytes of the Markdown, configured by the JFROG_CLI_COMMAND_SUMMARY_MAX_SIZE environment variable.
+// If the variable isn't set, the size of the Markdown is limited only in GitHub Actions, which limits the size of job summaries.
+// Zero means no limit.
+func getMarkdownSizeBudget() (int, error) {
+	maxSize := os.Getenv(coreutils.SummaryMaxSizeEnv)
+	if maxSize == "" {
+		if os.Getenv(gitHubActionsEnv) == "true" {
+			return gitHubMarkdownSizeBudget, nil
+		}
+		return 0, nil
+	}
+	budget, err := strconv.Atoi(maxSize)
+	if err != nil || budget < 0 {
+		return 0, errorutils.CheckErrorf("invalid value '%s' for the %s environment variable. A non-negative number of bytes is expected", maxSize, coreutils.SummaryMaxSizeEnv)
+	}
+	return budget, nil
+}
+
+// Fit the Markdown into the size budget.
+// If the Markdown exceeds the budget, the full Markdown is saved to a sidecar file, and the sections of the summary model are truncated
+// in priority order, until the Markdown fits. Summaries without a model are replaced by a note, which links to the sidecar file.
+func (cs *CommandSummary) fitMarkdownToBudget(markdown string, getModel func() (*SummaryModel, error)) (string, error) {
+	budget, err := getMarkdownSizeBudget()
+	if err != nil || budget == 0 || len(markdown) <= budget {
+		return markdown, err
+	}
+	log.Warn(fmt.Sprintf("The %s command summary exceeds the size limit of %d bytes, and is truncated.", cs.commandsName, budget))
+	if err = createAndWriteToFile(cs.summaryOutputPath, fullMarkdownFileName, []byte(markdown)); err != nil {
+		return "", err
+	}
+	note := getTruncationNote(budget, filepath.Join(OutputDirName, cs.commandsName, fullMarkdownFileName))
+	model, err := getModel()
+	if err != nil {
+		return "", err
+	}
+	if model != nil {
+		var truncatedMarkdown string
+		if truncatedMarkdown, err = truncateModelToBudget(model, note, budget); err != nil || truncatedMarkdown != "" {
+			return truncatedMarkdown, err
+		}
+	}
+	return note + "\n\n", nil
+}
+
+// Returns the note of a truncated summary. The path of the full Markdown is relative to the command summary output directory,
+// because the local path isn't reachable from the rendered summary.
+func getTruncationNote(budget int, fullMarkdownPath string) string {
+	return fmt.Sprintf("⚠️ This summary was truncated to fit the size limit of %d bytes. The full summary is saved to the <code>%s</code> file, "+
+		"under the directory set by the %s environment variable.", budget, filepath.ToSlash(fullMarkdownPath), coreutils.SummaryOutputDirPathEnv)
+}
+
+// Fit the combined Markdown of the command summaries into the size budget.
+// If the Markdown exceeds the budget, the full Markdown is saved to a sidecar file, and the last summaries are omitted until the Markdown fits.
+func fitFinalMarkdownToBudget(summaryDir string, markdowns []string) (string, error) {
+	finalMarkdown := strings.Join(markdowns, "")
+	budget, err := getMarkdownSizeBudget()
+	if err != nil || budget == 0 || len(finalMarkdown) <= budget {
+		return finalMarkdown, err
+	}
+	log.Warn(fmt.Sprintf("The combined command summary exceeds the size limit of %d bytes, and is truncated.", budget))
+	if err = createAndWriteToFile(summaryDir, fullMarkdownFileName, []byte(finalMarkdown)); err != nil {
+		return "", err
+	}
+	note := getTruncationNote(budget, filepath.Join(OutputDirName, fullMarkdownFileName)) + "\n\n"
+	size := len(note) + len(finalMarkdown)
+	for len(markdowns) > 0 && size > budget {
+		size -= len(markdowns[len(markdowns)-1])
+		markdowns = markdowns[:len(markdowns)-1]
+	}
+	return note + strings.Join(markdowns, ""), nil
+}
+
+// The content of a section before its truncation.
+type truncatedSection struct {
+	section *ModelSection
+	text    string
+	files   []ModelLink
+	rows    [][]ModelLink
+}
+
+// Halve the files and table rows of the section, and note how many of them are shown. Returns false if there's nothing left to truncate.
+func (ts *truncatedSection) truncate() bool {
+	section := ts.section
+	switch {
+	case len(section.Files) > 0:
+		section.Files = section.Files[:len(section.Files)/2]
+	case section.Table != nil && len(section.Table.Rows) > 0:
+		section.Table.Rows = section.Table.Rows[:len(section.Table.Rows)/2]
+	default:
+		return false
+	}
+	var truncationNotes []string
+	if len(ts.files) > 0 {
+		truncationNotes = append(truncationNotes, fmt.Sprintf("Showing %d of %d files.", len(section.Files), len(ts.files)))
+	}
+	if len(ts.rows) > 0 {
+		truncationNotes = append(truncationNotes, fmt.Sprintf("Showing %d of %d rows.", len(section.Table.Rows), len(ts.rows)))
+	}
+	section.Text = ts.text
+	if section.Text != "" {
+		section.Text += "\n\n"
+	}
+	section.Text += "<i>" + strings.Join(truncationNotes, " ") + "</i>"
+	if section.Title != "" {
+		section.Collapsed = true
+	}
+	return true
+}
+
+// Truncate a copy of the model, until its Markdown fits into the budget.
+// Sections with a lower priority are truncated first, and among sections of the same priority, the last sections are truncated first.
+// Returns an empty string if the Markdown doesn't fit even after truncating all the sections.
+func truncateModelToBudget(model *SummaryModel, note string, budget int) (string, error) {
+	model, err := cloneModel(model)
+	if err != nil {
+		return "", err
+	}
+	model.Sections = append([]*ModelSection{{Text: note, Priority: math.MaxInt}}, model.Sections...)
+	var sections []*truncatedSection
+	var collectSections func(modelSections []*ModelSection)
+	collectSections = func(modelSections []*ModelSection) {
+		for _, section := range modelSections {
+			truncated := &truncatedSection{section: section, text: section.Text, files: section.Files}
+			if section.Table != nil {
+				truncated.rows = section.Table.Rows
+			}
+			sections = append(sections, truncated)
+			collectSections(section.Sections)
+		}
+	}
+	collectSections(model.Sections)
+	slices.Reverse(sections)
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].section.Priority < sections[j].section.Priority
+	})
+
+	renderer := &MarkdownRenderer{}
+	// The Markdown rendered from the model may be smaller than the Markdown of the summary implementation
+	markdown, err := renderer.Render(model)
+	if err != nil || len(markdown) <= budget {
+		return markdown, err
+	}
+	for _, section := range sections {
+		for section.truncate() {
+			if markdown, err = renderer.Render(model); err != nil || len(markdown) <= budget {
+				return markdown, err
+			}
+		}
+	}
+	return "", nil
+}
+
+// The model is shared by all the renderers, so it's copied before its truncation.
+func cloneModel(model *SummaryModel) (*SummaryModel, error) {
+	content, err := json.Marshal(model)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	clone := new(SummaryModel)
+	return clone, errorutils.CheckError(json.Unmarshal(content, clone))
+}
@@ -0,0 +1,102 @@
+package commandsummary
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMarkdownSizeBudget(t *testing.T) {
+	// No limit by default
+	t.Setenv(gitHubActionsEnv, "")
+	budget, err := getMarkdownSizeBudget()
+	assert.NoError(t, err)
+	assert.Zero(t, budget)
+
+	// The job summaries of GitHub Actions are limited
+	t.Setenv(gitHubActionsEnv, "true")
+	budget, err = getMarkdownSizeBudget()
+	assert.NoError(t, err)
+	assert.Equal(t, gitHubMarkdownSizeBudget, budget)
+
+	// The environment variable takes precedence
+	t.Setenv(coreutils.SummaryMaxSizeEnv, "0")
+	budget, err = getMarkdownSizeBudget()
+	assert.NoError(t, err)
+	assert.Zero(t, budget)
+
+	t.Setenv(coreutils.SummaryMaxSizeEnv, "2048")
+	budget, err = getMarkdownSizeBudget()
+	assert.NoError(t, err)
+	assert.Equal(t, 2048, budget)
+
+	t.Setenv(coreutils.SummaryMaxSizeEnv, "1MB")
+	_, err = getMarkdownSizeBudget()
+	assert.ErrorContains(t, err, "invalid value '1MB'")
+}
+
+func TestGenerateMarkdownWithinBudget(t *testing.T) {
+	cs, cleanUp := prepareTest(t)
+	defer cleanUp()
+	cs.CommandSummaryInterface = &DownloadSummary{}
+	var results DownloadResultsWrapper
+	for i := 0; i < 100; i++ {
+		results.Results = append(results.Results, DownloadResult{SourceRepo: "generic-local", SourcePath: fmt.Sprintf("dir/file-%d.zip", i), TargetPath: fmt.Sprintf("out/file-%d.zip", i)})
+	}
+	assert.NoError(t, cs.Record(results))
+	t.Setenv(coreutils.SummaryMaxSizeEnv, "2048")
+	assert.NoError(t, cs.GenerateMarkdown())
+
+	markdown, err := os.ReadFile(filepath.Join(cs.summaryOutputPath, finalMarkdownFileName))
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(markdown), 2048)
+	assert.Contains(t, string(markdown), "⚠️ This summary was truncated to fit the size limit of 2048 bytes")
+	assert.Contains(t, string(markdown), "<code>jfrog-command-summary/testsCommands/markdown-full.md</code>")
+	assert.Contains(t, string(markdown), "<i>Showing 25 of 100 rows.</i>")
+	assert.Contains(t, string(markdown), "| generic-local/dir/file-0.zip | out/file-0.zip |  |")
+
+	// The full Markdown is kept in the sidecar file
+	fullMarkdown, err := os.ReadFile(filepath.Join(cs.summaryOutputPath, fullMarkdownFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(fullMarkdown), "| generic-local/dir/file-99.zip | out/file-99.zip |  |")
+
+	// A summary without a model is replaced by the note
+	cs.CommandSummaryInterface = &mockCommandSummary{}
+	t.Setenv(coreutils.SummaryMaxSizeEnv, "10")
+	assert.NoError(t, cs.GenerateMarkdown())
+	markdown, err = os.ReadFile(filepath.Join(cs.summaryOutputPath, finalMarkdownFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(markdown), "⚠️ This summary was truncated to fit the size limit of 10 bytes")
+}
+
+func TestGenerateFinalMarkdownWithinBudget(t *testing.T) {
+	outputDir := t.TempDir()
+	t.Setenv(coreutils.SummaryOutputDirPathEnv, outputDir)
+	for _, command := range []string{"build-info", "upload", "download"} {
+		cs, err := New(&mockCommandSummary{}, command)
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(filepath.Join(cs.summaryOutputPath, finalMarkdownFileName), []byte(strings.Repeat(command[:1], 500)), 0600))
+	}
+	t.Setenv(coreutils.SummaryMaxSizeEnv, "1400")
+	assert.NoError(t, WriteFinalMarkdown(outputDir))
+
+	markdown, err := os.ReadFile(filepath.Join(outputDir, OutputDirName, finalMarkdownFileName))
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(markdown), 1400)
+	// The note points to the full Markdown relatively to the output directory
+	assert.Contains(t, string(markdown), "⚠️ This summary was truncated to fit the size limit of 1400 bytes. The full summary is saved to the <code>jfrog-command-summary/markdown-full.md</code> file")
+	assert.NotContains(t, string(markdown), outputDir)
+	// The last summary is omitted
+	assert.Contains(t, string(markdown), strings.Repeat("b", 500)+strings.Repeat("u", 500))
+	assert.NotContains(t, string(markdown), "ddd")
+
+	// The full Markdown is kept in the sidecar file
+	fullMarkdown, err := os.ReadFile(filepath.Join(outputDir, OutputDirName, fullMarkdownFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("b", 500)+strings.Repeat("u", 500)+strings.Repeat("d", 500), string(fullMarkdown))
+}
@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"sort"
+	"strconv"
 	"strings"
 
 	buildInfo "github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	clientUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/maps"
 )
 
 const (
@@ -50,16 +54,8 @@ func (bis *BuildInfoSummary) GetSummaryTitle() string {
 }
 
 func (bis *BuildInfoSummary) GenerateMarkdownFromFiles(dataFilePaths []string) (finalMarkdown string, err error) {
-	// Aggregate all the build info files into a slice
-	var builds []*buildInfo.BuildInfo
-	for _, filePath := range dataFilePaths {
-		var publishBuildInfo buildInfo.BuildInfo
-		if err = UnmarshalFromFilePath(filePath, &publishBuildInfo); err != nil {
-			return
-		}
-		builds = append(builds, &publishBuildInfo)
-	}
-	if len(builds) == 0 {
+	builds, err := loadBuilds(dataFilePaths)
+	if err != nil || len(builds) == 0 {
 		return
 	}
 
@@ -79,6 +75,142 @@ func (bis *BuildInfoSummary) GenerateMarkdownFromFiles(dataFilePaths []string) (
 	return WrapCollapsableMarkdown(bis.GetSummaryTitle(), finalMarkdown, 3), nil
 }
 
+func (bis *BuildInfoSummary) GenerateModelFromFiles(dataFilePaths []string) (*SummaryModel, error) {
+	builds, err := loadBuilds(dataFilePaths)
+	if err != nil {
+		return nil, err
+	}
+	model := NewSummaryModel(bis.GetSummaryTitle())
+	buildsSection := model.AddSection("Build Info")
+	// The builds table is kept when the summary is truncated
+	buildsSection.Priority = 1
+	buildsTable := buildsSection.SetTable("Build Info", "Security Violations", "Security Issues")
+	for _, build := range builds {
+		buildName := build.Name + " " + build.Number
+		violations, vulnerabilities := getScanResultsText(buildName)
+		buildsTable.AddRow(ModelLink{Text: buildName, Url: build.BuildUrl}, TextCell(violations), TextCell(vulnerabilities))
+	}
+	modulesSection := model.AddSection(modulesTitle)
+	for _, build := range builds {
+		groupedModules := groupModules(filterModules(build.Modules...))
+		rootModuleIds := maps.Keys(groupedModules)
+		sort.Strings(rootModuleIds)
+		for _, rootModuleId := range rootModuleIds {
+			if err = addModulesToModel(modulesSection.AddSection(rootModuleId), rootModuleId, groupedModules[rootModuleId]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return model, nil
+}
+
+// Add the artifacts of a root module and its nested modules to the section of the root module.
+// Nested modules, such as the platform images of a multi-platform image, are added as collapsed sections.
+func addModulesToModel(rootSection *ModelSection, rootModuleId string, modules []buildInfo.Module) error {
+	isMultiModule := len(modules) > 1
+	if scannableModuleType[modules[0].Type] {
+		violations, vulnerabilities := getScanResultsText(extractDockerImageTag(modules))
+		rootSection.SetTable("Security Violations", "Security Issues").AddRow(TextCell(violations), TextCell(vulnerabilities))
+	}
+	for _, module := range modules {
+		if isMultiModule && rootModuleId == module.Id {
+			continue
+		}
+		moduleSection := rootSection
+		if isMultiModule {
+			moduleSection = rootSection.AddSection(module.Id)
+			moduleSection.Collapsed = true
+		}
+		for _, artifact := range module.Artifacts {
+			var artifactUrl string
+			if StaticMarkdownConfig.IsExtendedSummary() {
+				var err error
+				if artifactUrl, err = generateArtifactUrl(artifact, module); err != nil {
+					return err
+				}
+			}
+			moduleSection.AddFile(path.Join(artifact.OriginalDeploymentRepo, artifact.Path), artifactUrl)
+		}
+		if err := addDockerLayersToModel(moduleSection, module); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addDockerLayersToModel(moduleSection *ModelSection, module buildInfo.Module) error {
+	layersTable, err := getDockerLayersTable(module)
+	if err != nil || layersTable == nil {
+		return err
+	}
+	layersSection := moduleSection.AddSection(layersTable.title)
+	layersSection.Collapsed = true
+	if layersTable.baseImage != "" {
+		layersSection.Text = fmt.Sprintf("Base image: %s (%s)", layersTable.baseImage, layersTable.baseImageSize)
+	}
+	modelTable := layersSection.SetTable(dockerLayersTableHeaders...)
+	for _, row := range layersTable.rows {
+		var cells []ModelLink
+		for _, cell := range row {
+			cells = append(cells, TextCell(cell))
+		}
+		modelTable.AddRow(cells...)
+	}
+	return nil
+}
+
+var dockerLayersTableHeaders = []string{"#", "Size", "Instruction", "Base Image"}
+
+// The table of the docker image layers, shared by the Markdown and the model of the summary.
+type dockerLayersTable struct {
+	title string
+	// The base image reference e.g. alpine:3.19@sha256:<hex>, or empty if the base image wasn't detected.
+	baseImage     string
+	baseImageSize string
+	rows          [][]string
+}
+
+// Returns the table of the docker image layers, with their size, the instruction which created them and whether they belong to the base image.
+// Returns nil if the module has no layers details.
+func getDockerLayersTable(module buildInfo.Module) (*dockerLayersTable, error) {
+	if module.Type != buildInfo.Docker {
+		return nil, nil
+	}
+	layersDetails, err := container.GetLayersDetails(module)
+	if err != nil || len(layersDetails) == 0 {
+		return nil, err
+	}
+	layersTable := &dockerLayersTable{}
+	var totalSize, baseImageSize int64
+	for i, layer := range layersDetails {
+		totalSize += layer.Size
+		baseImageMark := ""
+		if layer.BaseImage {
+			baseImageSize += layer.Size
+			baseImageMark = "✅"
+		}
+		layersTable.rows = append(layersTable.rows, []string{strconv.Itoa(i + 1), clientUtils.ConvertIntToStorageSizeString(layer.Size), layer.CreatedBy, baseImageMark})
+	}
+	layersTable.title = fmt.Sprintf("🧱 %s layers (%d layers, %s)", module.Id, len(layersDetails), clientUtils.ConvertIntToStorageSizeString(totalSize))
+	if baseImageName := getModuleProperty(module, container.BaseImageNameProperty); baseImageName != "" {
+		layersTable.baseImage = baseImageName + "@" + getModuleProperty(module, container.BaseImageDigestProperty)
+		layersTable.baseImageSize = clientUtils.ConvertIntToStorageSizeString(baseImageSize)
+	}
+	return layersTable, nil
+}
+
+// Aggregate all the build info files into a slice
+func loadBuilds(dataFilePaths []string) (builds []*buildInfo.BuildInfo, err error) {
+	for _, filePath := range dataFilePaths {
+		var publishBuildInfo buildInfo.BuildInfo
+		if err = UnmarshalFromFilePath(filePath, &publishBuildInfo); err != nil {
+			return
+		}
+		builds = append(builds, &publishBuildInfo)
+	}
+	return
+}
+
 // Create a table with published builds and possible scan results.
 func (bis *BuildInfoSummary) buildInfoTable(builds []*buildInfo.BuildInfo) (string, error) {
 	var tableBuilder strings.Builder
@@ -181,9 +313,45 @@ func (bis *BuildInfoSummary) generateModuleTableView(rootModuleID string, subMod
 	}
 	scanResult := getScanResults(extractDockerImageTag(subModules))
 	markdownBuilder.WriteString(generateTableRow(nestedModuleMarkdownTree, scanResult))
+	for _, module := range subModules {
+		layersMarkdown, err := generateDockerLayersMarkdown(module)
+		if err != nil {
+			return "", err
+		}
+		markdownBuilder.WriteString(layersMarkdown)
+	}
 	return markdownBuilder.String(), nil
 }
 
+// Generates a collapsible table of the docker image layers, with their size, the instruction which created them and whether they belong to the base image.
+// Returns an empty string if the module has no layers details.
+func generateDockerLayersMarkdown(module buildInfo.Module) (string, error) {
+	layersTable, err := getDockerLayersTable(module)
+	if err != nil || layersTable == nil {
+		return "", err
+	}
+	var tableBuilder strings.Builder
+	tableBuilder.WriteString("\n\n| " + strings.Join(dockerLayersTableHeaders, " | ") + " |\n|" + strings.Repeat(":---|", len(dockerLayersTableHeaders)) + "\n")
+	for _, row := range layersTable.rows {
+		var cells []string
+		for _, cell := range row {
+			cells = append(cells, escapeMarkdownTableCell(cell))
+		}
+		tableBuilder.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	var baseImageMarkdown string
+	if layersTable.baseImage != "" {
+		baseImageMarkdown = fmt.Sprintf("\n\nBase image: <code>%s</code> (%s)", layersTable.baseImage, layersTable.baseImageSize)
+	}
+	return "\n\n" + createCollapsibleSection(layersTable.title, baseImageMarkdown+tableBuilder.String()) + "\n\n", nil
+}
+
+// Escape the characters which break a Markdown table cell, such as pipes and new lines in RUN instructions.
+func escapeMarkdownTableCell(str string) string {
+	str = strings.ReplaceAll(str, "|", "\\|")
+	return strings.ReplaceAll(str, "\n", " ")
+}
+
 func (bis *BuildInfoSummary) generateTableModuleMarkdown(nestedModules []buildInfo.Module, parentModuleID string, isMultiModule bool) (string, error) {
 	var nestedModuleMarkdownTree strings.Builder
 	if len(nestedModules) == 0 {
@@ -372,27 +540,25 @@ func getScanResults(scannedEntity string) (sc ScanResult) {
 	return StaticMarkdownConfig.scanResultsMapping[NonScannedResult]
 }
 
+// Returns the violations and vulnerabilities of the scanned entity, or empty strings if there are no scan results.
+func getScanResultsText(scannedEntity string) (violations, vulnerabilities string) {
+	if scanResult := getScanResults(scannedEntity); scanResult != nil {
+		return scanResult.GetViolations(), scanResult.GetVulnerabilities()
+	}
+	return
+}
+
 func extractDockerImageTag(modules []buildInfo.Module) string {
 	if len(modules) == 0 || modules[0].Type != buildInfo.Docker {
 		return ""
 	}
 
-	const tagKey = "docker.image.tag"
-	properties := modules[0].Properties
-	switch props := properties.(type) {
-	case map[string]interface{}:
-		if tag, found := props[tagKey]; found {
-			if tagStr, ok := tag.(string); ok {
-				return tagStr
-			}
-		}
-	case map[string]string:
-		if tag, found := props[tagKey]; found {
-			return tag
-		}
-	}
+	return getModuleProperty(modules[0], "docker.image.tag")
+}
 
-	return ""
+// Returns the value of a module property. The properties are a map of strings, or a map of interfaces if unmarshalled from a file.
+func getModuleProperty(module buildInfo.Module, key string) string {
+	return container.GetModuleProperty(module, key)
 }
 
 // Filter out unsupported modules, return empty list if no supported modules found.
@@ -1,6 +1,7 @@
 package commandsummary
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -9,6 +10,7 @@ import (
 
 	buildInfo "github.com/jfrog/build-info-go/entities"
 	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,6 +22,7 @@ const (
 	mavenModule           = "maven-module.md"
 	mavenNestedModule     = "maven-nested-module.md"
 	dockerMultiArchModule = "multiarch-docker-image.md"
+	dockerImageLayers     = "docker-image-layers.md"
 )
 
 type MockScanResult struct {
@@ -331,6 +334,35 @@ func TestDockerModule(t *testing.T) {
 
 }
 
+func TestDockerImageLayers(t *testing.T) {
+	_, cleanUp := prepareBuildInfoTest()
+	defer cleanUp()
+	module := buildinfo.Module{
+		Id:   "image:2",
+		Type: buildinfo.Docker,
+		Properties: map[string]interface{}{
+			container.BaseImageNameProperty:   "alpine:3.19",
+			container.BaseImageDigestProperty: "sha256:aae9",
+			"docker.image.layer.1.digest":     "sha256:552c",
+			"docker.image.layer.1.size":       "3408896",
+			"docker.image.layer.1.createdBy":  "ADD file:base in /",
+			"docker.image.layer.1.baseImage":  "true",
+			"docker.image.layer.2.digest":     "sha256:bb1f",
+			"docker.image.layer.2.size":       "2048",
+			"docker.image.layer.2.createdBy":  "RUN apk add curl | tee log",
+		},
+	}
+	res, err := generateDockerLayersMarkdown(module)
+	assert.NoError(t, err)
+	testMarkdownOutput(t, getTestDataFile(t, dockerImageLayers), res)
+
+	// Modules without layers details have no layers section
+	module.Properties = map[string]string{"docker.image.tag": "image:2"}
+	res, err = generateDockerLayersMarkdown(module)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
+
 func TestDockerMultiArchModule(t *testing.T) {
 	buildInfoSummary, cleanUp := prepareBuildInfoTest()
 	defer func() {
@@ -661,3 +693,39 @@ func testMarkdownOutput(t *testing.T, expected, actual string) {
 	}
 	assert.Equal(t, expected, actual)
 }
+
+func TestBuildInfoSummaryModel(t *testing.T) {
+	buildInfoSummary, cleanUp := prepareBuildInfoTest()
+	defer cleanUp()
+	tempDir := t.TempDir()
+	dataFile := filepath.Join(tempDir, "build-data")
+	content, err := json.Marshal(buildinfo.BuildInfo{
+		Name:     "my-build",
+		Number:   "1",
+		BuildUrl: buildUrl,
+		Modules: []buildinfo.Module{{
+			Id:         "image:2",
+			Type:       buildinfo.Docker,
+			Properties: map[string]string{"docker.image.layer.1.digest": "sha256:552c", "docker.image.layer.1.size": "2048", "docker.image.layer.1.createdBy": "COPY app /app"},
+			Artifacts:  []buildinfo.Artifact{{Name: "manifest.json", Path: "image/2/manifest.json", OriginalDeploymentRepo: "docker-local"}},
+		}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(dataFile, content, 0644))
+
+	model, err := buildInfoSummary.GenerateModelFromFiles([]string{dataFile})
+	if !assert.NoError(t, err) || !assert.Len(t, model.Sections, 2) {
+		return
+	}
+	assert.Equal(t, []ModelLink{{Text: "my-build 1", Url: buildUrl}, TextCell("Not scanned"), TextCell("Not scanned")}, model.Sections[0].Table.Rows[0])
+	if assert.Len(t, model.Sections[1].Sections, 1) {
+		moduleSection := model.Sections[1].Sections[0]
+		assert.Equal(t, "image:2", moduleSection.Title)
+		assert.Equal(t, []ModelLink{{Text: "docker-local/image/2/manifest.json"}}, moduleSection.Files)
+		if assert.Len(t, moduleSection.Sections, 1) {
+			// The model has the same layers table as the Markdown
+			assert.Equal(t, "🧱 image:2 layers (1 layers, 2.0KB)", moduleSection.Sections[0].Title)
+			assert.Equal(t, [][]ModelLink{{TextCell("1"), TextCell("2.0KB"), TextCell("COPY app /app"), TextCell("")}}, moduleSection.Sections[0].Table.Rows)
+		}
+	}
+}
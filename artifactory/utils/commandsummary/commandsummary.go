@@ -85,6 +85,10 @@ func New(userImplementation CommandSummaryInterface, commandsName string) (cs *C
 	if outputDir == "" {
 		return nil, fmt.Errorf("output dir path is not defined,please set the JFROG_CLI_COMMAND_SUMMARY_OUTPUT_DIR environment variable")
 	}
+	return newCommandSummary(userImplementation, commandsName, outputDir)
+}
+
+func newCommandSummary(userImplementation CommandSummaryInterface, commandsName, outputDir string) (cs *CommandSummary, err error) {
 	cs = &CommandSummary{
 		CommandSummaryInterface: userImplementation,
 		commandsName:            commandsName,
@@ -94,7 +98,10 @@ func New(userImplementation CommandSummaryInterface, commandsName string) (cs *C
 	return
 }
 
-// Loads all the relevant data files and invoke the implementation to generate the Markdown.
+// Loads all the relevant data files and invoke the implementation to generate the summary in the configured output formats.
+// The Markdown is always generated by GenerateMarkdownFromFiles, as it's tailored for GitHub job summaries.
+// If the implementation also implements CommandSummaryModelInterface, the summary model is generated once, and the other formats are rendered from it.
+// Otherwise, the other formats are skipped.
 func (cs *CommandSummary) GenerateMarkdown() error {
 	dataFilesPaths, err := cs.GetDataFilesPaths()
 	if err != nil {
@@ -103,12 +110,47 @@ func (cs *CommandSummary) GenerateMarkdown() error {
 	if len(dataFilesPaths) == 0 {
 		return nil
 	}
-	markdown, err := cs.GenerateMarkdownFromFiles(dataFilesPaths)
+	renderers, err := GetConfiguredRenderers()
 	if err != nil {
-		return fmt.Errorf("failed to render markdown: %w", err)
+		return err
 	}
-	if err = cs.saveMarkdownFile(markdown); err != nil {
-		return fmt.Errorf("failed to save markdown to file system: %w", err)
+	// The model is generated once and rendered by all the renderers.
+	var model *SummaryModel
+	getModel := func() (*SummaryModel, error) {
+		modelGenerator, ok := cs.CommandSummaryInterface.(CommandSummaryModelInterface)
+		if !ok || model != nil {
+			return model, nil
+		}
+		if model, err = modelGenerator.GenerateModelFromFiles(dataFilesPaths); err != nil {
+			return nil, fmt.Errorf("failed to generate the summary model: %w", err)
+		}
+		return model, nil
+	}
+	for _, renderer := range renderers {
+		var output string
+		if _, isMarkdown := renderer.(*MarkdownRenderer); isMarkdown {
+			if output, err = cs.GenerateMarkdownFromFiles(dataFilesPaths); err != nil {
+				return fmt.Errorf("failed to render markdown: %w", err)
+			}
+			// GitHub limits the size of the job summary
+			if output, err = cs.fitMarkdownToBudget(output, getModel); err != nil {
+				return err
+			}
+		} else {
+			if model, err = getModel(); err != nil {
+				return err
+			}
+			if model == nil {
+				log.Warn("The", cs.commandsName, "command summary doesn't support the", renderer.FileName(), "output, which is skipped.")
+				continue
+			}
+			if output, err = renderer.Render(model); err != nil {
+				return fmt.Errorf("failed to render %s: %w", renderer.FileName(), err)
+			}
+		}
+		if err = createAndWriteToFile(cs.summaryOutputPath, renderer.FileName(), []byte(output)); err != nil {
+			return fmt.Errorf("failed to save %s to file system: %w", renderer.FileName(), err)
+		}
 	}
 	return nil
 }
@@ -132,6 +174,16 @@ func (cs *CommandSummary) RecordWithIndex(data any, summaryIndex Index, args ...
 	return cs.recordInternal(data, summaryIndex, args)
 }
 
+// The RecordWithKey function saves data under the given key within the command summary directory.
+// Recording data with the same key again replaces the previously recorded data.
+//
+// Data: The data to be recorded.
+// Key: The unique key of the data, used to determine the file name.
+func (cs *CommandSummary) RecordWithKey(data any, key string) (err error) {
+	log.Debug("Recording data with key:", key)
+	return cs.recordInternal(data, []string{key})
+}
+
 // Retrieve all the indexed data files in the current command directory.
 func GetIndexedDataFilesPaths() (indexedFilePathsMap IndexedFilesMap, err error) {
 	basePath := filepath.Join(os.Getenv(coreutils.SummaryOutputDirPathEnv), OutputDirName)
@@ -147,10 +199,10 @@ func (cs *CommandSummary) GetDataFilesPaths() ([]string, error) {
 	if err != nil {
 		return nil, errorutils.CheckError(err)
 	}
-	// Exclude markdown files
+	// Exclude the generated output files
 	var filePaths []string
 	for _, entry := range entries {
-		if !entry.IsDir() && !strings.HasSuffix(entry.Name(), ".md") {
+		if !entry.IsDir() && !isOutputFile(entry.Name()) {
 			filePaths = append(filePaths, filepath.Join(cs.summaryOutputPath, entry.Name()))
 		}
 	}
@@ -177,9 +229,8 @@ func (cs *CommandSummary) saveDataFile(filePath, fileName string, data any) (err
 	return createAndWriteToFile(filePath, fileName, dataAsBytes)
 }
 
-func (cs *CommandSummary) saveMarkdownFile(markdown string) (err error) {
-	data := []byte(markdown)
-	return createAndWriteToFile(cs.summaryOutputPath, finalMarkdownFileName, data)
+func isOutputFile(fileName string) bool {
+	return strings.HasSuffix(fileName, ".md") || fileName == (&HtmlRenderer{}).FileName() || fileName == (&JsonRenderer{}).FileName()
 }
 
 // Retrieve all the indexed data files paths in the given directory
@@ -201,6 +201,21 @@ func TestSarifMultipleReports(t *testing.T) {
 	}
 }
 
+func TestRecordWithKey(t *testing.T) {
+	cs, cleanUp := prepareTest(t)
+	defer cleanUp()
+	// Recording data with the same key replaces it
+	assert.NoError(t, cs.RecordWithKey("first", "key"))
+	assert.NoError(t, cs.RecordWithKey("second", "key"))
+	assert.NoError(t, cs.RecordWithKey("other", "other-key"))
+	dataFilePaths, err := cs.GetDataFilesPaths()
+	assert.NoError(t, err)
+	assert.Len(t, dataFilePaths, 2)
+	var data string
+	assert.NoError(t, UnmarshalFromFilePath(filepath.Join(cs.summaryOutputPath, fileNameToSha1("key")), &data))
+	assert.Equal(t, "second", data)
+}
+
 func TestExtractIndexAndArgs(t *testing.T) {
 	tests := []struct {
 		name          string
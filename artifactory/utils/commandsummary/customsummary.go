@@ -0,0 +1,138 @@
+package commandsummary
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const customSummaryCommandName = "custom"
+
+// Renders sections recorded by users, with their own data and Go templates.
+type CustomSummary struct {
+	CommandSummary
+}
+
+// A section recorded by the user.
+type CustomSectionRecord struct {
+	// The title of the section. Recording a section with the same name again replaces it.
+	Name string `json:"name"`
+	// A Go template (text/template), which renders the data of the section as Markdown.
+	Template string `json:"template"`
+	// The structured data of the section, available to the template as '.'
+	Data any `json:"data"`
+	// Sections are ordered by their order, and then by their names.
+	Order int `json:"order,omitempty"`
+}
+
+func NewCustomSummary() (*CommandSummary, error) {
+	return New(&CustomSummary{}, customSummaryCommandName)
+}
+
+// Record a custom section and regenerate the custom sections summary.
+// The template is validated against the data, so that errors are reported when recording, rather than when generating the summary.
+func RecordCustomSection(section CustomSectionRecord) error {
+	if strings.TrimSpace(section.Name) == "" {
+		return errorutils.CheckErrorf("the name of a custom summary section must not be empty")
+	}
+	if _, err := section.render(); err != nil {
+		return err
+	}
+	cs, err := NewCustomSummary()
+	if err != nil {
+		return err
+	}
+	// Sections are recorded by their name, so that recording a section again replaces it.
+	if err = cs.RecordWithKey(section, section.Name); err != nil {
+		return err
+	}
+	return cs.GenerateMarkdown()
+}
+
+func (cs *CustomSummary) GetSummaryTitle() string {
+	return "📝 Custom Sections"
+}
+
+// Each custom section is rendered as a top-level section of the summary, like the summaries of the commands.
+func (cs *CustomSummary) GenerateMarkdownFromFiles(dataFilePaths []string) (markdown string, err error) {
+	sections, err := loadCustomSections(dataFilePaths)
+	if err != nil {
+		return
+	}
+	var markdownBuilder strings.Builder
+	for _, section := range sections {
+		sectionMarkdown, err := section.render()
+		if err != nil {
+			return "", err
+		}
+		markdownBuilder.WriteString(WrapCollapsableMarkdown(section.Name, sectionMarkdown, 3))
+	}
+	return markdownBuilder.String(), nil
+}
+
+func (cs *CustomSummary) GenerateModelFromFiles(dataFilePaths []string) (*SummaryModel, error) {
+	sections, err := loadCustomSections(dataFilePaths)
+	if err != nil {
+		return nil, err
+	}
+	model := NewSummaryModel(cs.GetSummaryTitle())
+	// Each custom section is a top-level section of the summary, like the summaries of the commands
+	model.TopLevelSections = true
+	for _, section := range sections {
+		sectionText, err := section.render()
+		if err != nil {
+			return nil, err
+		}
+		modelSection := model.AddSection(section.Name)
+		modelSection.Text = sectionText
+		modelSection.Markdown = true
+	}
+	return model, nil
+}
+
+func loadCustomSections(dataFilePaths []string) ([]CustomSectionRecord, error) {
+	var sections []CustomSectionRecord
+	for _, dataFilePath := range dataFilePaths {
+		var section CustomSectionRecord
+		if err := UnmarshalFromFilePath(dataFilePath, &section); err != nil {
+			return nil, err
+		}
+		sections = append(sections, section)
+	}
+	sort.Slice(sections, func(i, j int) bool {
+		if sections[i].Order != sections[j].Order {
+			return sections[i].Order < sections[j].Order
+		}
+		return sections[i].Name < sections[j].Name
+	})
+	return sections, nil
+}
+
+func (section *CustomSectionRecord) render() (string, error) {
+	sectionTemplate, err := template.New(section.Name).Option("missingkey=error").Parse(section.Template)
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to parse the template of the '%s' summary section: %s", section.Name, err.Error())
+	}
+	// The data is rendered as recorded in the data file, regardless of the type it was recorded from.
+	data, err := normalizeData(section.Data)
+	if err != nil {
+		return "", err
+	}
+	var buffer bytes.Buffer
+	if err = sectionTemplate.Execute(&buffer, data); err != nil {
+		return "", errorutils.CheckErrorf("failed to render the '%s' summary section: %s", section.Name, err.Error())
+	}
+	return buffer.String(), nil
+}
+
+func normalizeData(data any) (normalized any, err error) {
+	content, err := json.Marshal(data)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return normalized, errorutils.CheckError(json.Unmarshal(content, &normalized))
+}
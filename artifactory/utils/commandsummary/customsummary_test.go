@@ -0,0 +1,68 @@
+package commandsummary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordCustomSection(t *testing.T) {
+	outputDir := t.TempDir()
+	t.Setenv(coreutils.SummaryOutputDirPathEnv, outputDir)
+	testsTemplate := "{{range .suites}}- {{.name}}: {{.passed}} passed\n{{end}}"
+	assert.NoError(t, RecordCustomSection(CustomSectionRecord{Name: "Tests", Template: testsTemplate, Data: map[string]any{
+		"suites": []map[string]any{{"name": "unit", "passed": 10}},
+	}}))
+	assert.NoError(t, RecordCustomSection(CustomSectionRecord{Name: "Coverage", Template: "Coverage: {{.}}%", Data: 85.5}))
+	// Recording a section again replaces it
+	assert.NoError(t, RecordCustomSection(CustomSectionRecord{Name: "Tests", Template: testsTemplate, Data: map[string]any{
+		"suites": []map[string]any{{"name": "unit", "passed": 12}, {"name": "e2e", "passed": 3}},
+	}}))
+	// Sections are ordered by their order, and then by their names
+	assert.NoError(t, RecordCustomSection(CustomSectionRecord{Name: "Release Notes", Template: "Nothing new", Order: -1}))
+
+	markdown, err := os.ReadFile(filepath.Join(outputDir, OutputDirName, customSummaryCommandName, finalMarkdownFileName))
+	assert.NoError(t, err)
+	assert.Equal(t,
+		WrapCollapsableMarkdown("Release Notes", "Nothing new", 3)+
+			WrapCollapsableMarkdown("Coverage", "Coverage: 85.5%", 3)+
+			WrapCollapsableMarkdown("Tests", "- unit: 12 passed\n- e2e: 3 passed\n", 3),
+		string(markdown))
+}
+
+func TestCustomSummaryModel(t *testing.T) {
+	outputDir := t.TempDir()
+	t.Setenv(coreutils.SummaryOutputDirPathEnv, outputDir)
+	assert.NoError(t, RecordCustomSection(CustomSectionRecord{Name: "Coverage", Template: "**Coverage:** {{.}}%", Data: 85.5}))
+	cs, err := NewCustomSummary()
+	assert.NoError(t, err)
+	dataFilePaths, err := cs.GetDataFilesPaths()
+	assert.NoError(t, err)
+	model, err := (&CustomSummary{}).GenerateModelFromFiles(dataFilePaths)
+	assert.NoError(t, err)
+	// The rendered template is marked as Markdown, so that it isn't rendered as plain text
+	assert.Equal(t, []*ModelSection{{Title: "Coverage", Text: "**Coverage:** 85.5%", Markdown: true}}, model.Sections)
+}
+
+func TestRecordInvalidCustomSection(t *testing.T) {
+	t.Setenv(coreutils.SummaryOutputDirPathEnv, t.TempDir())
+	assert.ErrorContains(t, RecordCustomSection(CustomSectionRecord{Template: "text"}), "must not be empty")
+	assert.ErrorContains(t, RecordCustomSection(CustomSectionRecord{Name: "Tests", Template: "{{.count"}), "failed to parse the template of the 'Tests' summary section")
+	assert.ErrorContains(t, RecordCustomSection(CustomSectionRecord{Name: "Tests", Template: "{{.count}}", Data: map[string]any{}}), "failed to render the 'Tests' summary section")
+}
+
+func TestGenerateFinalMarkdown(t *testing.T) {
+	outputDir := t.TempDir()
+	t.Setenv(coreutils.SummaryOutputDirPathEnv, outputDir)
+	for _, command := range []string{customSummaryCommandName, "upload", "my-plugin", "build-info", "another-plugin"} {
+		cs, err := New(&mockCommandSummary{}, command)
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(filepath.Join(cs.summaryOutputPath, finalMarkdownFileName), []byte(command+"\n"), 0600))
+	}
+	markdown, err := GenerateFinalMarkdown(outputDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "build-info\nupload\nanother-plugin\nmy-plugin\ncustom\n", markdown)
+}
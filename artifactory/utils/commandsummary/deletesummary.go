@@ -0,0 +1,43 @@
+package commandsummary
+
+type DeleteSummary struct {
+	CommandSummary
+}
+
+type DeleteResult struct {
+	// The path of the deleted artifact or folder in Artifactory, including the repository e.g. repo/dir/file.zip
+	Path  string `json:"path"`
+	RtUrl string `json:"rtUrl,omitempty"`
+}
+
+type DeleteResultsWrapper struct {
+	Results []DeleteResult `json:"results"`
+}
+
+func NewDeleteSummary() (*CommandSummary, error) {
+	return New(&DeleteSummary{}, "delete")
+}
+
+func (ds *DeleteSummary) GetSummaryTitle() string {
+	return "🗑️ Files deleted from Artifactory by this workflow"
+}
+
+func (ds *DeleteSummary) GenerateMarkdownFromFiles(dataFilePaths []string) (markdown string, err error) {
+	return generateMarkdownFromModel(ds, dataFilePaths)
+}
+
+func (ds *DeleteSummary) GenerateModelFromFiles(dataFilePaths []string) (*SummaryModel, error) {
+	model := NewSummaryModel(ds.GetSummaryTitle())
+	// Deleted paths can't be linked to Artifactory.
+	deletedSection := model.AddSection("")
+	for _, dataFilePath := range dataFilePaths {
+		var deleteResults DeleteResultsWrapper
+		if err := UnmarshalFromFilePath(dataFilePath, &deleteResults); err != nil {
+			return nil, err
+		}
+		for _, result := range deleteResults.Results {
+			deletedSection.AddFile(result.Path, "")
+		}
+	}
+	return model, nil
+}
@@ -0,0 +1,22 @@
+package commandsummary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteSummary(t *testing.T) {
+	cs, cleanUp := prepareTest(t)
+	defer cleanUp()
+	cs.CommandSummaryInterface = &DeleteSummary{}
+	assert.NoError(t, cs.Record(DeleteResultsWrapper{Results: []DeleteResult{{Path: "generic-local/dir/a.zip"}, {Path: "generic-local/dir/b.zip"}}}))
+	assert.NoError(t, cs.GenerateMarkdown())
+
+	markdown, err := os.ReadFile(filepath.Join(cs.summaryOutputPath, finalMarkdownFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(markdown), "🗑️ Files deleted from Artifactory by this workflow")
+	assert.Contains(t, string(markdown), "<pre>📦 generic-local\n└── 📁 dir\n    ├── 📄 a.zip\n    └── 📄 b.zip\n\n</pre>")
+}
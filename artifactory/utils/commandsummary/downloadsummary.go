@@ -0,0 +1,59 @@
+package commandsummary
+
+import "path"
+
+type DownloadSummary struct {
+	CommandSummary
+}
+
+type DownloadResult struct {
+	// The repository and the path of the downloaded artifact in Artifactory.
+	SourceRepo string `json:"sourceRepo"`
+	SourcePath string `json:"sourcePath"`
+	// The local path to which the artifact was downloaded.
+	TargetPath string `json:"targetPath"`
+	Sha256     string `json:"sha256,omitempty"`
+	Sha1       string `json:"sha1,omitempty"`
+	RtUrl      string `json:"rtUrl,omitempty"`
+}
+
+type DownloadResultsWrapper struct {
+	Results []DownloadResult `json:"results"`
+}
+
+func NewDownloadSummary() (*CommandSummary, error) {
+	return New(&DownloadSummary{}, "download")
+}
+
+func (ds *DownloadSummary) GetSummaryTitle() string {
+	return "📥 Files downloaded from Artifactory by this workflow"
+}
+
+func (ds *DownloadSummary) GenerateMarkdownFromFiles(dataFilePaths []string) (markdown string, err error) {
+	return generateMarkdownFromModel(ds, dataFilePaths)
+}
+
+func (ds *DownloadSummary) GenerateModelFromFiles(dataFilePaths []string) (*SummaryModel, error) {
+	var downloadedArtifacts DownloadResultsWrapper
+	for _, dataFilePath := range dataFilePaths {
+		var downloadResults DownloadResultsWrapper
+		if err := UnmarshalFromFilePath(dataFilePath, &downloadResults); err != nil {
+			return nil, err
+		}
+		downloadedArtifacts.Results = append(downloadedArtifacts.Results, downloadResults.Results...)
+	}
+	model := NewSummaryModel(ds.GetSummaryTitle())
+	artifactsTable := model.AddSection("").SetTable("Artifact", "Downloaded To", "SHA256")
+	for _, result := range downloadedArtifacts.Results {
+		artifactPath := path.Join(result.SourceRepo, result.SourcePath)
+		var artifactUrl string
+		if StaticMarkdownConfig.IsExtendedSummary() {
+			var err error
+			if artifactUrl, err = GenerateArtifactUrl(artifactPath, artifactsSection); err != nil {
+				return nil, err
+			}
+		}
+		artifactsTable.AddRow(ModelLink{Text: artifactPath, Url: artifactUrl}, TextCell(result.TargetPath), TextCell(result.Sha256))
+	}
+	return model, nil
+}
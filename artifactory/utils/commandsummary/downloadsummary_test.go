@@ -0,0 +1,29 @@
+package commandsummary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadSummary(t *testing.T) {
+	cs, cleanUp := prepareTest(t)
+	defer cleanUp()
+	cs.CommandSummaryInterface = &DownloadSummary{}
+	assert.NoError(t, cs.Record(DownloadResultsWrapper{Results: []DownloadResult{
+		{SourceRepo: "generic-local", SourcePath: "dir/file.zip", TargetPath: "out/file.zip", Sha256: "abc123"},
+	}}))
+	assert.NoError(t, cs.Record(DownloadResultsWrapper{Results: []DownloadResult{
+		{SourceRepo: "generic-local", SourcePath: "dir/other|file.txt", TargetPath: "out/other|file.txt", Sha256: "def456"},
+	}}))
+	assert.NoError(t, cs.GenerateMarkdown())
+
+	markdown, err := os.ReadFile(filepath.Join(cs.summaryOutputPath, finalMarkdownFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(markdown), "📥 Files downloaded from Artifactory by this workflow")
+	assert.Contains(t, string(markdown), "| Artifact | Downloaded To | SHA256 |\n|:---|:---|:---|\n")
+	assert.Contains(t, string(markdown), "| generic-local/dir/file.zip | out/file.zip | abc123 |\n")
+	assert.Contains(t, string(markdown), `| generic-local/dir/other\|file.txt | out/other\|file.txt | def456 |`)
+}
@@ -0,0 +1,75 @@
+package commandsummary
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// The order of the built-in summaries in the final Markdown.
+// The summaries of other commands follow in the order of their names, and the custom sections come last.
+var builtInSummariesOrder = []string{"build-info", "security", "evidence", "upload", "download", "delete", "transfer-files"}
+
+// Combine the Markdown of all the command summaries under outputDir into the final Markdown, in a deterministic order.
+// The final Markdown is fitted into the size budget, like the Markdown of each command summary.
+func GenerateFinalMarkdown(outputDir string) (string, error) {
+	summaryDir := filepath.Join(outputDir, OutputDirName)
+	exists, err := fileutils.IsDirExists(summaryDir, false)
+	if err != nil || !exists {
+		return "", err
+	}
+	entries, err := os.ReadDir(summaryDir)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	var commands []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			commands = append(commands, entry.Name())
+		}
+	}
+	sort.SliceStable(commands, func(i, j int) bool {
+		return getSummaryRank(commands[i]) < getSummaryRank(commands[j])
+	})
+	var markdowns []string
+	for _, command := range commands {
+		markdownPath := filepath.Join(summaryDir, command, finalMarkdownFileName)
+		exists, err = fileutils.IsFileExists(markdownPath, false)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			continue
+		}
+		markdown, err := os.ReadFile(markdownPath)
+		if err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		markdowns = append(markdowns, string(markdown))
+	}
+	return fitFinalMarkdownToBudget(summaryDir, markdowns)
+}
+
+// Combine the Markdown of all the command summaries under outputDir, and write the final Markdown to the command summary directory.
+func WriteFinalMarkdown(outputDir string) error {
+	finalMarkdown, err := GenerateFinalMarkdown(outputDir)
+	if err != nil || finalMarkdown == "" {
+		return err
+	}
+	return createAndWriteToFile(filepath.Join(outputDir, OutputDirName), finalMarkdownFileName, []byte(finalMarkdown))
+}
+
+// Returns the rank of the command summary in the final Markdown. Commands of the same rank are ordered by their names.
+func getSummaryRank(command string) int {
+	if index := slices.Index(builtInSummariesOrder, command); index >= 0 {
+		return index
+	}
+	if command == customSummaryCommandName {
+		return len(builtInSummariesOrder) + 1
+	}
+	return len(builtInSummariesOrder)
+}
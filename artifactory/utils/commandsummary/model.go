@@ -0,0 +1,89 @@
+package commandsummary
+
+// The intermediate model of a command summary.
+// The model is produced once from the recorded data files, and rendered to each of the selected output formats.
+type SummaryModel struct {
+	Title    string          `json:"title"`
+	Sections []*ModelSection `json:"sections,omitempty"`
+	// If true, the Markdown renders each section as a top-level summary, instead of nesting the sections under the title of the model.
+	TopLevelSections bool `json:"topLevelSections,omitempty"`
+}
+
+// A titled section of the summary. A section may include text, a table, a list of files and nested sections.
+type ModelSection struct {
+	Title string `json:"title,omitempty"`
+	Text  string `json:"text,omitempty"`
+	// If true, the text is Markdown. Renderers which don't support Markdown render it as preformatted text.
+	Markdown bool        `json:"markdown,omitempty"`
+	Table    *ModelTable `json:"table,omitempty"`
+	// Files in Artifactory, rendered as a files tree where supported.
+	Files    []ModelLink     `json:"files,omitempty"`
+	Sections []*ModelSection `json:"sections,omitempty"`
+	// If true, the section is rendered collapsed by renderers which support it.
+	Collapsed bool `json:"collapsed,omitempty"`
+	// When the Markdown exceeds its size budget, sections with a lower priority are truncated first.
+	Priority int `json:"priority,omitempty"`
+}
+
+type ModelTable struct {
+	Headers []string      `json:"headers"`
+	Rows    [][]ModelLink `json:"rows"`
+}
+
+// A text, which links to a URL if the URL isn't empty. Used for table cells and files.
+type ModelLink struct {
+	Text string `json:"text"`
+	Url  string `json:"url,omitempty"`
+}
+
+// Implement this interface, in addition to CommandSummaryInterface, to support all the output formats of the command summary.
+// The GenerateModelFromFiles function should load the data from the provided data file paths and convert it into a SummaryModel.
+type CommandSummaryModelInterface interface {
+	GenerateModelFromFiles(dataFilePaths []string) (model *SummaryModel, err error)
+}
+
+func NewSummaryModel(title string) *SummaryModel {
+	return &SummaryModel{Title: title}
+}
+
+// Add a section to the model and return it.
+func (sm *SummaryModel) AddSection(title string) *ModelSection {
+	section := &ModelSection{Title: title}
+	sm.Sections = append(sm.Sections, section)
+	return section
+}
+
+// Add a nested section and return it.
+func (ms *ModelSection) AddSection(title string) *ModelSection {
+	section := &ModelSection{Title: title}
+	ms.Sections = append(ms.Sections, section)
+	return section
+}
+
+// Set the table headers and return the table, to which rows can be added.
+func (ms *ModelSection) SetTable(headers ...string) *ModelTable {
+	ms.Table = &ModelTable{Headers: headers}
+	return ms.Table
+}
+
+func (ms *ModelSection) AddFile(path, url string) {
+	ms.Files = append(ms.Files, ModelLink{Text: path, Url: url})
+}
+
+func (mt *ModelTable) AddRow(cells ...ModelLink) {
+	mt.Rows = append(mt.Rows, cells)
+}
+
+// Create a table cell without a link.
+func TextCell(text string) ModelLink {
+	return ModelLink{Text: text}
+}
+
+// Generate the Markdown of summaries, which have no Markdown of their own, by rendering their model.
+func generateMarkdownFromModel(modelGenerator CommandSummaryModelInterface, dataFilePaths []string) (string, error) {
+	model, err := modelGenerator.GenerateModelFromFiles(dataFilePaths)
+	if err != nil {
+		return "", err
+	}
+	return (&MarkdownRenderer{}).Render(model)
+}
@@ -0,0 +1,197 @@
+package commandsummary
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type OutputFormat string
+
+const (
+	MarkdownFormat OutputFormat = "markdown"
+	HtmlFormat     OutputFormat = "html"
+	JsonFormat     OutputFormat = "json"
+)
+
+// Renders a summary model into an output format.
+type Renderer interface {
+	Render(model *SummaryModel) (string, error)
+	// The name of the rendered file, which is saved in the command summary directory.
+	FileName() string
+}
+
+// Returns the renderers of the output formats, configured by the JFROG_CLI_COMMAND_SUMMARY_FORMAT environment variable.
+// The variable holds a comma-separated list of formats e.g. markdown,html. The default is markdown.
+func GetConfiguredRenderers() ([]Renderer, error) {
+	formats := os.Getenv(coreutils.SummaryOutputFormatEnv)
+	if formats == "" {
+		return []Renderer{&MarkdownRenderer{}}, nil
+	}
+	var renderers []Renderer
+	for _, format := range strings.Split(formats, ",") {
+		renderer, err := GetRenderer(OutputFormat(strings.ToLower(strings.TrimSpace(format))))
+		if err != nil {
+			return nil, err
+		}
+		renderers = append(renderers, renderer)
+	}
+	return renderers, nil
+}
+
+func GetRenderer(format OutputFormat) (Renderer, error) {
+	switch format {
+	case MarkdownFormat:
+		return &MarkdownRenderer{}, nil
+	case HtmlFormat:
+		return &HtmlRenderer{}, nil
+	case JsonFormat:
+		return &JsonRenderer{}, nil
+	}
+	return nil, errorutils.CheckErrorf("unsupported command summary format '%s'. Supported formats: %s, %s, %s", format, MarkdownFormat, HtmlFormat, JsonFormat)
+}
+
+// Renders the model as GitHub flavored Markdown, with collapsible sections.
+type MarkdownRenderer struct{}
+
+func (mr *MarkdownRenderer) FileName() string {
+	return finalMarkdownFileName
+}
+
+func (mr *MarkdownRenderer) Render(model *SummaryModel) (string, error) {
+	var markdownBuilder strings.Builder
+	for _, section := range model.Sections {
+		if model.TopLevelSections {
+			untitledSection := *section
+			untitledSection.Title = ""
+			markdownBuilder.WriteString(WrapCollapsableMarkdown(section.Title, strings.TrimSuffix(mr.renderSection(&untitledSection, 3), "\n\n"), 3))
+			continue
+		}
+		markdownBuilder.WriteString(mr.renderSection(section, 3))
+	}
+	if model.TopLevelSections {
+		return markdownBuilder.String(), nil
+	}
+	return WrapCollapsableMarkdown(model.Title, markdownBuilder.String(), 3), nil
+}
+
+func (mr *MarkdownRenderer) renderSection(section *ModelSection, headerSize int) string {
+	var markdownBuilder strings.Builder
+	if section.Text != "" {
+		markdownBuilder.WriteString(section.Text + "\n\n")
+	}
+	if section.Table != nil {
+		markdownBuilder.WriteString(mr.renderTable(section.Table))
+	}
+	if len(section.Files) > 0 {
+		filesTree := utils.NewFileTree()
+		for _, file := range section.Files {
+			filesTree.AddFile(file.Text, file.Url)
+			if filesTree.IsTreeExceedsMax() {
+				break
+			}
+		}
+		markdownBuilder.WriteString(fmt.Sprintf("<pre>%s</pre>\n\n", filesTree.String()))
+	}
+	for _, subSection := range section.Sections {
+		markdownBuilder.WriteString(mr.renderSection(subSection, min(headerSize+1, 6)))
+	}
+	if section.Title == "" {
+		return markdownBuilder.String()
+	}
+	if section.Collapsed {
+		return createCollapsibleSection(section.Title, "\n"+markdownBuilder.String()) + "\n\n"
+	}
+	return fmt.Sprintf("%s %s\n\n%s", strings.Repeat("#", headerSize), section.Title, markdownBuilder.String())
+}
+
+func (mr *MarkdownRenderer) renderTable(table *ModelTable) string {
+	var tableBuilder strings.Builder
+	tableBuilder.WriteString("| " + strings.Join(table.Headers, " | ") + " |\n")
+	tableBuilder.WriteString(strings.Repeat("|:---", len(table.Headers)) + "|\n")
+	for _, row := range table.Rows {
+		for _, cell := range row {
+			text := escapeMarkdownTableCell(cell.Text)
+			if cell.Url != "" {
+				text = fmt.Sprintf("[%s](%s)", text, cell.Url)
+			}
+			tableBuilder.WriteString("| " + text + " ")
+		}
+		tableBuilder.WriteString("|\n")
+	}
+	return tableBuilder.String() + "\n"
+}
+
+// Renders the model as a self-contained HTML page, which requires no external resources.
+type HtmlRenderer struct{}
+
+const htmlTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292f; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #d0d7de; padding: 6px 13px; text-align: left; }
+th { background-color: #f6f8fa; }
+ul.files { font-family: monospace; }
+pre.markdown { white-space: pre-wrap; font-family: inherit; }
+summary { cursor: pointer; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Sections}}{{template "section" .}}{{end}}
+</body>
+</html>
+{{define "section"}}{{if .Title}}<details{{if not .Collapsed}} open{{end}}>
+<summary><strong>{{.Title}}</strong></summary>{{else}}<div>{{end}}
+{{if .Text}}{{if .Markdown}}<pre class="markdown">{{.Text}}</pre>{{else}}<p>{{.Text}}</p>{{end}}{{end}}
+{{with .Table}}<table>
+<tr>{{range .Headers}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td>{{template "link" .}}</td>{{end}}</tr>
+{{end}}</table>{{end}}
+{{if .Files}}<ul class="files">
+{{range .Files}}<li>{{template "link" .}}</li>
+{{end}}</ul>{{end}}
+{{range .Sections}}{{template "section" .}}{{end}}
+{{if .Title}}</details>{{else}}</div>{{end}}
+{{end}}
+{{define "link"}}{{if .Url}}<a href="{{.Url}}" target="_blank">{{.Text}}</a>{{else}}{{.Text}}{{end}}{{end}}`
+
+var parsedHtmlTemplate = template.Must(template.New("summary").Parse(htmlTemplate))
+
+func (hr *HtmlRenderer) FileName() string {
+	return "summary.html"
+}
+
+func (hr *HtmlRenderer) Render(model *SummaryModel) (string, error) {
+	var htmlBuffer bytes.Buffer
+	if err := parsedHtmlTemplate.Execute(&htmlBuffer, model); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return htmlBuffer.String(), nil
+}
+
+// Renders the model as JSON, to be consumed by other tools.
+type JsonRenderer struct{}
+
+func (jr *JsonRenderer) FileName() string {
+	return "summary.json"
+}
+
+func (jr *JsonRenderer) Render(model *SummaryModel) (string, error) {
+	content, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return string(content), nil
+}
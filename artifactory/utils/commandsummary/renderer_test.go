@@ -0,0 +1,105 @@
+package commandsummary
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockModelCommandSummary struct {
+	mockCommandSummary
+}
+
+func (mcs *mockModelCommandSummary) GenerateModelFromFiles(_ []string) (*SummaryModel, error) {
+	return createTestSummaryModel(), nil
+}
+
+func createTestSummaryModel() *SummaryModel {
+	model := NewSummaryModel("Test Summary")
+	buildsSection := model.AddSection("Builds")
+	buildsTable := buildsSection.SetTable("Build", "Status")
+	buildsTable.AddRow(ModelLink{Text: "my-build 1", Url: "https://acme.jfrog.io/builds/my-build/1"}, TextCell("<script>alert(1)</script>"))
+	filesSection := buildsSection.AddSection("Files")
+	filesSection.Collapsed = true
+	filesSection.AddFile("repo/dir/file.txt", "")
+	notesSection := model.AddSection("Notes")
+	notesSection.Text = "- **first**\n- second"
+	notesSection.Markdown = true
+	return model
+}
+
+func TestGetConfiguredRenderers(t *testing.T) {
+	renderers, err := GetConfiguredRenderers()
+	assert.NoError(t, err)
+	assert.Equal(t, []Renderer{&MarkdownRenderer{}}, renderers)
+
+	t.Setenv(coreutils.SummaryOutputFormatEnv, "HTML, json")
+	renderers, err = GetConfiguredRenderers()
+	assert.NoError(t, err)
+	assert.Equal(t, []Renderer{&HtmlRenderer{}, &JsonRenderer{}}, renderers)
+
+	t.Setenv(coreutils.SummaryOutputFormatEnv, "pdf")
+	_, err = GetConfiguredRenderers()
+	assert.ErrorContains(t, err, "unsupported command summary format 'pdf'")
+}
+
+func TestMarkdownRenderer(t *testing.T) {
+	markdown, err := (&MarkdownRenderer{}).Render(createTestSummaryModel())
+	assert.NoError(t, err)
+	assert.Contains(t, markdown, "<h3> Test Summary </h3>")
+	assert.Contains(t, markdown, "### Builds\n\n| Build | Status |\n|:---|:---|\n| [my-build 1](https://acme.jfrog.io/builds/my-build/1) | <script>alert(1)</script> |\n")
+	assert.Contains(t, markdown, "<details><summary>Files</summary>\n\n<pre>📦 repo\n└── 📁 dir\n    └── 📄 file.txt\n\n</pre>")
+	assert.Contains(t, markdown, "### Notes\n\n- **first**\n- second\n\n")
+}
+
+func TestHtmlRenderer(t *testing.T) {
+	html, err := (&HtmlRenderer{}).Render(createTestSummaryModel())
+	assert.NoError(t, err)
+	assert.Contains(t, html, "<title>Test Summary</title>")
+	assert.Contains(t, html, `<td><a href="https://acme.jfrog.io/builds/my-build/1" target="_blank">my-build 1</a></td>`)
+	// Texts are escaped
+	assert.Contains(t, html, "<td>&lt;script&gt;alert(1)&lt;/script&gt;</td>")
+	assert.Contains(t, html, "<details>\n<summary><strong>Files</strong></summary>")
+	assert.Contains(t, html, "<li>repo/dir/file.txt</li>")
+	// Markdown texts are preformatted
+	assert.Contains(t, html, "<pre class=\"markdown\">- **first**\n- second</pre>")
+}
+
+func TestJsonRenderer(t *testing.T) {
+	content, err := (&JsonRenderer{}).Render(createTestSummaryModel())
+	assert.NoError(t, err)
+	var model SummaryModel
+	assert.NoError(t, json.Unmarshal([]byte(content), &model))
+	assert.Equal(t, createTestSummaryModel(), &model)
+}
+
+func TestGenerateMarkdownWithFormats(t *testing.T) {
+	cs, cleanUp := prepareTest(t)
+	defer cleanUp()
+	cs.CommandSummaryInterface = &mockModelCommandSummary{}
+	assert.NoError(t, cs.Record("data"))
+	t.Setenv(coreutils.SummaryOutputFormatEnv, "markdown,html,json")
+	assert.NoError(t, cs.GenerateMarkdown())
+
+	markdown, err := os.ReadFile(filepath.Join(cs.summaryOutputPath, finalMarkdownFileName))
+	assert.NoError(t, err)
+	// The Markdown is generated by the implementation, rather than rendered from the model
+	assert.Equal(t, "mockMarkdown", string(markdown))
+	assert.FileExists(t, filepath.Join(cs.summaryOutputPath, "summary.html"))
+	assert.FileExists(t, filepath.Join(cs.summaryOutputPath, "summary.json"))
+
+	// The generated files aren't data files
+	dataFiles, err := cs.GetDataFilesPaths()
+	assert.NoError(t, err)
+	assert.Len(t, dataFiles, 1)
+
+	// Summaries without a model are generated only as Markdown
+	cs.CommandSummaryInterface = &mockCommandSummary{}
+	assert.NoError(t, os.Remove(filepath.Join(cs.summaryOutputPath, "summary.json")))
+	assert.NoError(t, cs.GenerateMarkdown())
+	assert.NoFileExists(t, filepath.Join(cs.summaryOutputPath, "summary.json"))
+}
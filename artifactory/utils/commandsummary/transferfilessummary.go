@@ -0,0 +1,87 @@
+package commandsummary
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	clientUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+)
+
+type TransferFilesSummary struct {
+	CommandSummary
+}
+
+// The results of a single transfer-files run.
+type TransferFilesResult struct {
+	Started time.Time `json:"started"`
+	// The repositories results are cumulative, since a transfer of a repository may span several runs.
+	Repositories []TransferRepoResult `json:"repositories"`
+	// The totals of the run, as counted by the transfer run status.
+	TransferredFiles     int64  `json:"transferredFiles"`
+	TransferredSizeBytes int64  `json:"transferredSizeBytes"`
+	Failures             uint64 `json:"failures"`
+	DelayedFiles         uint64 `json:"delayedFiles"`
+}
+
+type TransferRepoResult struct {
+	Repository           string `json:"repository"`
+	TransferredFiles     int64  `json:"transferredFiles"`
+	TransferredSizeBytes int64  `json:"transferredSizeBytes"`
+	Failures             int    `json:"failures"`
+	DelayedFiles         int    `json:"delayedFiles"`
+}
+
+func NewTransferFilesSummary() (*CommandSummary, error) {
+	return New(&TransferFilesSummary{}, "transfer-files")
+}
+
+func (tfs *TransferFilesSummary) GetSummaryTitle() string {
+	return "🚚 Files transferred by this workflow"
+}
+
+func (tfs *TransferFilesSummary) GenerateMarkdownFromFiles(dataFilePaths []string) (markdown string, err error) {
+	return generateMarkdownFromModel(tfs, dataFilePaths)
+}
+
+func (tfs *TransferFilesSummary) GenerateModelFromFiles(dataFilePaths []string) (*SummaryModel, error) {
+	var results []TransferFilesResult
+	for _, dataFilePath := range dataFilePaths {
+		var result TransferFilesResult
+		if err := UnmarshalFromFilePath(dataFilePath, &result); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	// The results of a repository in a later run override its results in earlier runs.
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Started.Before(results[j].Started)
+	})
+	var total TransferFilesResult
+	reposIndexes := make(map[string]int)
+	for _, result := range results {
+		for _, repoResult := range result.Repositories {
+			if i, exists := reposIndexes[repoResult.Repository]; exists {
+				total.Repositories[i] = repoResult
+				continue
+			}
+			reposIndexes[repoResult.Repository] = len(total.Repositories)
+			total.Repositories = append(total.Repositories, repoResult)
+		}
+		total.TransferredFiles += result.TransferredFiles
+		total.TransferredSizeBytes += result.TransferredSizeBytes
+		total.Failures += result.Failures
+		total.DelayedFiles += result.DelayedFiles
+	}
+	model := NewSummaryModel(tfs.GetSummaryTitle())
+	section := model.AddSection("")
+	section.Text = fmt.Sprintf("Transferred %d files (%s) from %d repositories. Failures: %d. Delayed files: %d.",
+		total.TransferredFiles, clientUtils.ConvertIntToStorageSizeString(total.TransferredSizeBytes), len(total.Repositories), total.Failures, total.DelayedFiles)
+	reposTable := section.SetTable("Repository", "Files", "Size", "Failures", "Delayed Files")
+	for _, repo := range total.Repositories {
+		reposTable.AddRow(TextCell(repo.Repository), TextCell(strconv.FormatInt(repo.TransferredFiles, 10)),
+			TextCell(clientUtils.ConvertIntToStorageSizeString(repo.TransferredSizeBytes)), TextCell(strconv.Itoa(repo.Failures)), TextCell(strconv.Itoa(repo.DelayedFiles)))
+	}
+	return model, nil
+}
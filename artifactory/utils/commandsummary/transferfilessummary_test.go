@@ -0,0 +1,47 @@
+package commandsummary
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferFilesSummaryModel(t *testing.T) {
+	cs, cleanUp := prepareTest(t)
+	defer cleanUp()
+	transferSummary := &TransferFilesSummary{}
+	cs.CommandSummaryInterface = transferSummary
+	started := time.Now()
+	assert.NoError(t, cs.Record(TransferFilesResult{
+		Started: started,
+		Repositories: []TransferRepoResult{
+			{Repository: "maven-local", TransferredFiles: 10, TransferredSizeBytes: 2048, Failures: 1},
+			{Repository: "npm-local", TransferredFiles: 5, TransferredSizeBytes: 1024, DelayedFiles: 2},
+		},
+		TransferredFiles:     15,
+		TransferredSizeBytes: 3072,
+		Failures:             1,
+		DelayedFiles:         2,
+	}))
+	// A second run transfers the remaining files
+	assert.NoError(t, cs.Record(TransferFilesResult{
+		Started:              started.Add(time.Hour),
+		Repositories:         []TransferRepoResult{{Repository: "maven-local", TransferredFiles: 11, TransferredSizeBytes: 3072}},
+		TransferredFiles:     1,
+		TransferredSizeBytes: 1024,
+	}))
+	dataFiles, err := cs.GetDataFilesPaths()
+	assert.NoError(t, err)
+
+	model, err := transferSummary.GenerateModelFromFiles(dataFiles)
+	assert.NoError(t, err)
+	assert.Equal(t, "🚚 Files transferred by this workflow", model.Title)
+	if assert.Len(t, model.Sections, 1) {
+		section := model.Sections[0]
+		assert.Equal(t, "Transferred 16 files (4.0KB) from 2 repositories. Failures: 1. Delayed files: 2.", section.Text)
+		assert.Equal(t, []string{"Repository", "Files", "Size", "Failures", "Delayed Files"}, section.Table.Headers)
+		assert.Len(t, section.Table.Rows, 2)
+		assert.Equal(t, []ModelLink{TextCell("maven-local"), TextCell("11"), TextCell("3.0KB"), TextCell("0"), TextCell("0")}, section.Table.Rows[0])
+	}
+}
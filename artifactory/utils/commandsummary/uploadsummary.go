@@ -43,6 +43,22 @@ func (us *UploadSummary) GenerateMarkdownFromFiles(dataFilePaths []string) (mark
 	return
 }
 
+func (us *UploadSummary) GenerateModelFromFiles(dataFilePaths []string) (*SummaryModel, error) {
+	if err := us.loadResults(dataFilePaths); err != nil {
+		return nil, err
+	}
+	model := NewSummaryModel(us.GetSummaryTitle())
+	filesSection := model.AddSection("")
+	for _, uploadResult := range us.uploadedArtifacts.Results {
+		buildUiUrl, err := us.buildUiUrl(uploadResult.TargetPath)
+		if err != nil {
+			return nil, err
+		}
+		filesSection.AddFile(uploadResult.TargetPath, buildUiUrl)
+	}
+	return model, nil
+}
+
 // Loads all the recorded results from the given file paths.
 func (us *UploadSummary) loadResults(filePaths []string) error {
 	us.uploadedArtifacts = ResultsWrapper{}
@@ -0,0 +1,240 @@
+package container
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Annotations set by image builders such as BuildKit, which record the base image of the image.
+	baseImageNameAnnotation   = "org.opencontainers.image.base.name"
+	baseImageDigestAnnotation = "org.opencontainers.image.base.digest"
+
+	// Properties of the docker modules in the build-info.
+	BaseImageNameProperty   = "docker.image.base.name"
+	BaseImageDigestProperty = "docker.image.base.digest"
+	// The details of each layer are recorded in properties, which are prefixed with the layer number, starting from 1,
+	// e.g. docker.image.layer.1.digest, docker.image.layer.1.size, docker.image.layer.1.createdBy and docker.image.layer.1.baseImage.
+	LayerPropertyPrefix = "docker.image.layer."
+
+	// The scope of the dependencies, which are the layers of the base image.
+	BaseImageScope = "base-image"
+
+	// Long instructions, such as RUN instructions with inline scripts, are truncated in the layers details.
+	maxInstructionLength = 256
+)
+
+// The details of an image layer, as recorded in the build-info.
+type LayerDetails struct {
+	Digest string
+	Size   int64
+	// The instruction which created the layer e.g. COPY app /app
+	CreatedBy string
+	// True if the layer belongs to the base image.
+	BaseImage bool
+}
+
+// Record the details of the layers in the image properties.
+func setLayersDetails(layersDetails []LayerDetails, imageProperties map[string]string) {
+	for i, layerDetails := range layersDetails {
+		prefix := LayerPropertyPrefix + strconv.Itoa(i+1) + "."
+		imageProperties[prefix+"digest"] = layerDetails.Digest
+		imageProperties[prefix+"size"] = strconv.FormatInt(layerDetails.Size, 10)
+		if layerDetails.CreatedBy != "" {
+			imageProperties[prefix+"createdBy"] = layerDetails.CreatedBy
+		}
+		if layerDetails.BaseImage {
+			imageProperties[prefix+"baseImage"] = "true"
+		}
+	}
+}
+
+// The base image of an image, as found in Artifactory.
+type baseImageDetails struct {
+	name   string
+	digest string
+	// The digests of the image layers, which belong to the base image.
+	layers map[string]bool
+}
+
+// Returns the base image reference, which is recorded as the requester of the base image layers e.g. alpine:3.19@sha256:<hex>
+func (bid *baseImageDetails) requestedBy() [][]string {
+	return [][]string{{bid.name + "@" + bid.digest}}
+}
+
+// Read the layers details recorded in a docker module of the build-info.
+func GetLayersDetails(module buildinfo.Module) (layersDetails []LayerDetails, err error) {
+	for i := 1; ; i++ {
+		prefix := LayerPropertyPrefix + strconv.Itoa(i) + "."
+		digest := GetModuleProperty(module, prefix+"digest")
+		if digest == "" {
+			return
+		}
+		layerDetails := LayerDetails{Digest: digest, CreatedBy: GetModuleProperty(module, prefix+"createdBy")}
+		if layerDetails.Size, err = strconv.ParseInt(GetModuleProperty(module, prefix+"size"), 10, 64); err != nil {
+			return nil, errorutils.CheckErrorf("invalid size of layer %d: %s", i, err.Error())
+		}
+		if baseImage := GetModuleProperty(module, prefix+"baseImage"); baseImage != "" {
+			if layerDetails.BaseImage, err = strconv.ParseBool(baseImage); err != nil {
+				return nil, errorutils.CheckErrorf("invalid base image flag of layer %d: %s", i, err.Error())
+			}
+		}
+		layersDetails = append(layersDetails, layerDetails)
+	}
+}
+
+// Returns the value of a module property. The properties of a module, which was read from a build-info file, are a generic map.
+func GetModuleProperty(module buildinfo.Module, key string) string {
+	switch properties := module.Properties.(type) {
+	case map[string]string:
+		return properties[key]
+	case map[string]interface{}:
+		value, _ := properties[key].(string)
+		return value
+	}
+	return ""
+}
+
+// Get the image config from the builder or from Artifactory, and keep it for later use.
+func (builder *buildInfoBuilder) getImageConfig(candidateLayers map[string]*utils.ResultItem) (*configLayer, error) {
+	if builder.imageConfig != nil {
+		return builder.imageConfig, nil
+	}
+	configItem, ok := candidateLayers[digestToLayer(builder.imageSha2)]
+	if !ok {
+		return nil, errorutils.CheckErrorf("failed to collect build-info. Image '" + builder.imageSha2 + "' was not found in Artifactory")
+	}
+	imageConfig := new(configLayer)
+	if err := downloadLayer(*configItem, &imageConfig, builder.serviceManager, builder.repositoryDetails.key); err != nil {
+		return nil, err
+	}
+	builder.imageConfig = imageConfig
+	return imageConfig, nil
+}
+
+// Detect the base image of the pushed image, and record it and the details of the image layers in the image properties.
+// Must be called before the duplicate layers are removed from the manifest, since the config history refers to all the layers.
+func (builder *buildInfoBuilder) collectLayersDetails(imageManifest *manifest, candidateLayers map[string]*utils.ResultItem, imageProperties map[string]string) error {
+	imageConfig, err := builder.getImageConfig(candidateLayers)
+	if err != nil {
+		return err
+	}
+	// Failing to detect the base image shouldn't fail the build-info collection.
+	if builder.detectedBaseImage, err = builder.detectBaseImage(imageManifest, imageConfig); err != nil {
+		log.Warn("Failed to detect the base image of '" + builder.image.Name() + "': " + err.Error())
+	}
+	if builder.detectedBaseImage != nil {
+		log.Info("Found the base image " + builder.detectedBaseImage.name + "@" + builder.detectedBaseImage.digest + " with " + strconv.Itoa(len(builder.detectedBaseImage.layers)) + " layers")
+		imageProperties[BaseImageNameProperty] = builder.detectedBaseImage.name
+		imageProperties[BaseImageDigestProperty] = builder.detectedBaseImage.digest
+	}
+	setLayersDetails(imageConfig.getLayersDetails(imageManifest.Layers, candidateLayers, builder.detectedBaseImage), imageProperties)
+	return nil
+}
+
+// Find the base image manifest in Artifactory, and match its layers against the bottom layers of the image.
+// The base image is either set explicitly, or taken from the annotations of the image manifest. Returns nil if there's no base image.
+func (builder *buildInfoBuilder) detectBaseImage(imageManifest *manifest, imageConfig *configLayer) (*baseImageDetails, error) {
+	baseImage, manifestReference := builder.baseImage, ""
+	if baseImage == nil {
+		baseImageName := imageManifest.Annotations[baseImageNameAnnotation]
+		if baseImageName == "" {
+			return nil, nil
+		}
+		baseImage = NewImage(baseImageName)
+		manifestReference = imageManifest.Annotations[baseImageDigestAnnotation]
+	}
+	reference, err := baseImage.Reference()
+	if err != nil {
+		return nil, err
+	}
+	if manifestReference == "" {
+		manifestReference = reference.ManifestReference()
+	}
+	repo := builder.baseImageRepo
+	if repo == "" {
+		repo = builder.repositoryDetails.key
+	}
+	registryClient := NewArtifactoryRegistryClient(builder.serviceManager, repo)
+	imagePath := strings.TrimPrefix(reference.Path(), repo+"/")
+	registryManifest, err := registryClient.GetManifest(imagePath, manifestReference)
+	if err != nil {
+		return nil, err
+	}
+	if registryManifest.IsIndex() {
+		var index FatManifest
+		if err = json.Unmarshal(registryManifest.Content, &index); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		digest := searchManifestDigest(imageConfig.Os, imageConfig.Architecture, index.Manifests)
+		if digest == "" {
+			return nil, errorutils.CheckErrorf("the base image '%s' has no manifest for the platform %s/%s", baseImage.Name(), imageConfig.Os, imageConfig.Architecture)
+		}
+		if registryManifest, err = registryClient.GetManifest(imagePath, digest); err != nil {
+			return nil, err
+		}
+	}
+	baseManifest := new(manifest)
+	if err = json.Unmarshal(registryManifest.Content, baseManifest); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	// The base image layers are the bottom layers of the image.
+	baseImageLayers := make(map[string]bool)
+	for i, baseLayer := range baseManifest.Layers {
+		if i >= len(imageManifest.Layers) || imageManifest.Layers[i].Digest != baseLayer.Digest {
+			log.Warn("The layers of the image '" + builder.image.Name() + "' don't match the layers of the base image '" + baseImage.Name() + "'")
+			return nil, nil
+		}
+		baseImageLayers[baseLayer.Digest] = true
+	}
+	return &baseImageDetails{name: baseImage.Name(), digest: registryManifest.Digest, layers: baseImageLayers}, nil
+}
+
+// Match the image layers with the non-empty layers of the config history, to find the instruction which created each layer.
+func (configLayer *configLayer) getLayersDetails(layers []layer, candidateLayers map[string]*utils.ResultItem, baseImage *baseImageDetails) []LayerDetails {
+	var instructions []string
+	for _, historyEntry := range configLayer.History {
+		if !historyEntry.EmptyLayer {
+			instructions = append(instructions, normalizeInstruction(historyEntry.CreatedBy))
+		}
+	}
+	layersDetails := make([]LayerDetails, 0, len(layers))
+	for i, imageLayer := range layers {
+		layerDetails := LayerDetails{Digest: imageLayer.Digest, Size: imageLayer.Size}
+		if layerDetails.Size == 0 {
+			if item, ok := candidateLayers[digestToLayer(imageLayer.Digest)]; ok {
+				layerDetails.Size = item.Size
+			}
+		}
+		if i < len(instructions) {
+			layerDetails.CreatedBy = instructions[i]
+		}
+		if baseImage != nil {
+			layerDetails.BaseImage = baseImage.layers[imageLayer.Digest]
+		}
+		layersDetails = append(layersDetails, layerDetails)
+	}
+	return layersDetails
+}
+
+// Remove the shell prefix added by the Docker builder e.g. '/bin/sh -c #(nop) COPY app /app' -> 'COPY app /app',
+// and truncate long instructions.
+func normalizeInstruction(createdBy string) string {
+	instruction := strings.TrimSpace(createdBy)
+	if strings.HasPrefix(instruction, "/bin/sh -c #(nop)") {
+		instruction = strings.TrimSpace(strings.TrimPrefix(instruction, "/bin/sh -c #(nop)"))
+	} else if strings.HasPrefix(instruction, "/bin/sh -c ") {
+		instruction = "RUN " + strings.TrimPrefix(instruction, "/bin/sh -c ")
+	}
+	instruction = strings.TrimSuffix(instruction, " # buildkit")
+	if len(instruction) > maxInstructionLength {
+		instruction = instruction[:maxInstructionLength] + "..."
+	}
+	return instruction
+}
@@ -30,6 +30,7 @@ const (
 
 	ManifestJsonFile                  = "manifest.json"
 	AttestationsModuleIdPrefix string = "attestations"
+	ReferrersModuleIdPrefix    string = "referrers"
 )
 
 // Docker image build info builder.
@@ -50,6 +51,23 @@ type buildInfoBuilder struct {
 	// If true, don't set layers props in Artifactory.
 	skipTaggingLayers bool
 	imageLayers       []utils.ResultItem
+	// The image config, if it was already fetched by the builder. Otherwise, it is downloaded from Artifactory.
+	imageConfig *configLayer
+	// The artifacts attached to the image manifests, such as signatures and SBOMs, by the digest of the manifest they're attached to.
+	referrers map[string][]*attachedArtifact
+	// The base image of the image, if set explicitly, and the repository in which it's stored.
+	baseImage     *Image
+	baseImageRepo string
+	// The base image found in Artifactory, whose layers are the dependencies of the pushed image.
+	detectedBaseImage *baseImageDetails
+}
+
+// An artifact attached to an image manifest, and its files as they are stored in Artifactory.
+type attachedArtifact struct {
+	referrer *Referrer
+	// The subject of the artifact, which is the manifest to which the artifact is attached.
+	subject string
+	items   []*utils.ResultItem
 }
 
 // Create instance of docker build info builder.
@@ -78,6 +96,12 @@ func (builder *buildInfoBuilder) setImageSha2(imageSha2 string) {
 	builder.imageSha2 = imageSha2
 }
 
+// Set the base image of the image, and the repository in which it's stored. If the repository is empty, the image repository is used.
+func (builder *buildInfoBuilder) setBaseImage(baseImage *Image, repo string) {
+	builder.baseImage = baseImage
+	builder.baseImageRepo = repo
+}
+
 func (builder *buildInfoBuilder) GetLayers() *[]utils.ResultItem {
 	return &builder.imageLayers
 }
@@ -312,10 +336,16 @@ func (builder *buildInfoBuilder) createBuildInfo(commandType CommandType, manife
 			return nil, err
 		}
 	}
+	if commandType == Push {
+		if err := builder.collectLayersDetails(manifest, candidateLayers, imageProperties); err != nil {
+			return nil, err
+		}
+	}
 	// Manifest may hold 'empty layers'. As a result, promotion will fail to promote the same layer more than once.
 	manifest.Layers = removeDuplicateLayers(manifest.Layers)
 	var artifacts []buildinfo.Artifact
 	var dependencies []buildinfo.Dependency
+	var referrersModules []buildinfo.Module
 	var err error
 	switch commandType {
 	case Pull:
@@ -325,6 +355,7 @@ func (builder *buildInfoBuilder) createBuildInfo(commandType CommandType, manife
 		if err != nil {
 			return nil, err
 		}
+		referrersModules = builder.createReferrersModules("sha256:"+candidateLayers[ManifestJsonFile].Sha256, module)
 		if !builder.skipTaggingLayers {
 			if err := setBuildProperties(builder.buildName, builder.buildNumber, builder.project, builder.imageLayers, builder.serviceManager); err != nil {
 				return nil, err
@@ -338,6 +369,7 @@ func (builder *buildInfoBuilder) createBuildInfo(commandType CommandType, manife
 		Artifacts:    artifacts,
 		Dependencies: dependencies,
 	}}}
+	buildInfo.Modules = append(buildInfo.Modules, referrersModules...)
 	return buildInfo, nil
 }
 
@@ -362,6 +394,7 @@ func (builder *buildInfoBuilder) createMultiPlatformBuildInfo(fatManifest *FatMa
 		Properties: imageProperties,
 		Artifacts:  []buildinfo.Artifact{getFatManifestArtifact(searchResultFatManifest)},
 	}}}
+	buildInfo.Modules = append(buildInfo.Modules, builder.createReferrersModules("sha256:"+searchResultFatManifest.Sha256, baseModuleId)...)
 	imageLongNameWithoutRepo, err := builder.image.GetImageLongNameWithoutRepoWithTag()
 	if err != nil {
 		return nil, err
@@ -378,12 +411,14 @@ func (builder *buildInfoBuilder) createMultiPlatformBuildInfo(fatManifest *FatMa
 				artifacts = append(artifacts, layer.ToArtifact())
 			}
 		}
+		moduleId := getModuleIdByManifest(manifest, baseModuleId)
 		buildInfo.Modules = append(buildInfo.Modules, buildinfo.Module{
-			Id:        getModuleIdByManifest(manifest, baseModuleId),
+			Id:        moduleId,
 			Type:      buildinfo.Docker,
 			Artifacts: artifacts,
 			Parent:    imageLongNameWithoutRepo,
 		})
+		buildInfo.Modules = append(buildInfo.Modules, builder.createReferrersModules(manifest.Digest, moduleId)...)
 	}
 	return buildInfo, setBuildProperties(builder.buildName, builder.buildNumber, builder.project, builder.imageLayers, builder.serviceManager)
 }
@@ -399,6 +434,34 @@ func getModuleIdByManifest(manifest ManifestDetails, baseModuleId string) string
 	return baseModuleId
 }
 
+// Create a module for each of the artifacts attached to the manifest, such as signatures, attestations and SBOMs.
+// The modules are linked to the module of the manifest, and their files are added to the image layers.
+func (builder *buildInfoBuilder) createReferrersModules(subject, parentModuleId string) (modules []buildinfo.Module) {
+	for _, attached := range builder.referrers[subject] {
+		var artifacts []buildinfo.Artifact
+		for _, item := range attached.items {
+			builder.imageLayers = append(builder.imageLayers, *item)
+			if item.Name == ManifestJsonFile {
+				artifacts = append(artifacts, getManifestArtifact(item))
+			} else {
+				artifacts = append(artifacts, item.ToArtifact())
+			}
+		}
+		modules = append(modules, buildinfo.Module{
+			Id:   path.Join(ReferrersModuleIdPrefix, parentModuleId, digestToLayer(attached.referrer.Digest)),
+			Type: buildinfo.Docker,
+			Properties: map[string]string{
+				"oci.artifact.type":   attached.referrer.ArtifactType,
+				"oci.subject.digest":  attached.subject,
+				"oci.manifest.digest": attached.referrer.Digest,
+			},
+			Artifacts: artifacts,
+			Parent:    parentModuleId,
+		})
+	}
+	return
+}
+
 func (builder *buildInfoBuilder) createPushBuildProperties(imageManifest *manifest, candidateLayers map[string]*utils.ResultItem) (artifacts []buildinfo.Artifact, dependencies []buildinfo.Dependency, imageLayers []utils.ResultItem, err error) {
 	// Add artifacts.
 	artifacts = append(artifacts, getManifestArtifact(candidateLayers[ManifestJsonFile]))
@@ -409,7 +472,7 @@ func (builder *buildInfoBuilder) createPushBuildProperties(imageManifest *manife
 	imageLayers = append(imageLayers, *candidateLayers[digestToLayer(builder.imageSha2)])
 
 	totalLayers := len(imageManifest.Layers)
-	totalDependencies, err := builder.totalDependencies(candidateLayers[digestToLayer(builder.imageSha2)])
+	totalDependencies, err := builder.totalDependencies(candidateLayers)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -427,7 +490,15 @@ func (builder *buildInfoBuilder) createPushBuildProperties(imageManifest *manife
 		}
 
 		// Decide if the layer is also a dependency.
-		if i < totalDependencies {
+		// If the base image was found, its layers are the dependencies. Otherwise, the dependencies are decided by the config history.
+		if baseImage := builder.detectedBaseImage; baseImage != nil {
+			if baseImage.layers[imageManifest.Layers[i].Digest] {
+				dependency := item.ToDependency()
+				dependency.Scopes = []string{BaseImageScope}
+				dependency.RequestedBy = baseImage.requestedBy()
+				dependencies = append(dependencies, dependency)
+			}
+		} else if i < totalDependencies {
 			dependencies = append(dependencies, item.ToDependency())
 		}
 		artifacts = append(artifacts, item.ToArtifact())
@@ -484,10 +555,10 @@ func getDependenciesFromManifestLayer(layers map[string]*utils.ResultItem, image
 	return dependencies, nil
 }
 
-func (builder *buildInfoBuilder) totalDependencies(image *utils.ResultItem) (int, error) {
-	configurationLayer := new(configLayer)
-	if err := downloadLayer(*image, &configurationLayer, builder.serviceManager, builder.repositoryDetails.key); err != nil {
+func (builder *buildInfoBuilder) totalDependencies(candidateLayers map[string]*utils.ResultItem) (int, error) {
+	imageConfig, err := builder.getImageConfig(candidateLayers)
+	if err != nil {
 		return 0, err
 	}
-	return configurationLayer.getNumberOfDependentLayers(), nil
+	return imageConfig.getNumberOfDependentLayers(), nil
 }
@@ -9,32 +9,30 @@ import (
 )
 
 func TestGetManifestPaths(t *testing.T) {
-	results := getManifestPaths("/hello-world/latest", "docker-local", Push)
-	assert.Len(t, results, 2)
-	expected := []string{"docker-local/hello-world/latest/*", "docker-local/latest/*"}
-	for i, result := range results {
-		assert.Equal(t, expected[i], result)
+	testCases := []struct {
+		image       string
+		repo        string
+		commandType CommandType
+		expected    []string
+	}{
+		{"my-registry/hello-world:latest", "docker-local", Push, []string{"docker-local/hello-world/latest/*"}},
+		{"my-registry/hello-world:latest", "docker-local", Pull, []string{"docker-local/hello-world/latest/*", "docker-local/library/hello-world/latest/*"}},
+		{"my-registry/docker-remote/hello-world:latest", "docker-remote", Push, []string{"docker-remote/docker-remote/hello-world/latest/*", "docker-remote/hello-world/latest/*"}},
+		{"my-registry/docker-remote/hello-world:latest", "docker-remote", Pull, []string{"docker-remote/docker-remote/hello-world/latest/*", "docker-remote/hello-world/latest/*",
+			"docker-remote/library/docker-remote/hello-world/latest/*", "docker-remote/library/hello-world/latest/*"}},
+		{"my-registry/docker-remote/a/b/hello-world:latest", "docker-remote", Pull, []string{"docker-remote/docker-remote/a/b/hello-world/latest/*", "docker-remote/a/b/hello-world/latest/*"}},
+		// Image pushed by digest
+		{"my-registry/docker-local/hello-world@sha256:" + testDigestHex, "docker-local", Push, []string{"docker-local/docker-local/hello-world/sha256__" + testDigestHex + "/*", "docker-local/hello-world/sha256__" + testDigestHex + "/*"}},
+		// The tag is preferred over the digest, since Artifactory stores the manifest under the tag
+		{"my-registry/docker-local/hello-world:1.0@sha256:" + testDigestHex, "docker-local", Push, []string{"docker-local/docker-local/hello-world/1.0/*", "docker-local/hello-world/1.0/*"}},
 	}
-
-	results = getManifestPaths("/hello-world/latest", "docker-local", Pull)
-	assert.Len(t, results, 4)
-	expected = append(expected, "docker-local/library/hello-world/latest/*", "docker-local/library/latest/*")
-	for i, result := range results {
-		assert.Equal(t, expected[i], result)
-	}
-
-	results = getManifestPaths("/docker-remote/hello-world/latest", "docker-remote", Push)
-	assert.Len(t, results, 2)
-	expected = []string{"docker-remote/docker-remote/hello-world/latest/*", "docker-remote/hello-world/latest/*"}
-	for i, result := range results {
-		assert.Equal(t, expected[i], result)
-	}
-
-	results = getManifestPaths("/docker-remote/hello-world/latest", "docker-remote", Pull)
-	assert.Len(t, results, 4)
-	expected = append(expected, "docker-remote/library/docker-remote/hello-world/latest/*", "docker-remote/library/hello-world/latest/*")
-	for i, result := range results {
-		assert.Equal(t, expected[i], result)
+	for _, testCase := range testCases {
+		t.Run(testCase.image, func(t *testing.T) {
+			reference, err := ParseReference(testCase.image)
+			if assert.NoError(t, err) {
+				assert.Equal(t, testCase.expected, getManifestPaths(reference, testCase.repo, testCase.commandType))
+			}
+		})
 	}
 }
 
@@ -25,6 +25,18 @@ const MinSupportedApiVersion string = "1.31"
 // Docker login error message
 const LoginFailureMessage string = "%s login failed for: %s.\n%s image must be in the form: registry-domain/path-in-repository/image-name:version."
 
+// Minimum supported versions of the container managers, which don't provide an API version.
+var minSupportedClientVersions = map[ContainerManagerType]string{
+	Podman:  "2.0.0",
+	Buildah: "1.19.0",
+	Nerdctl: "1.0.0",
+}
+
+// Search for a client version e.g. 1.33.2 in the output of '<container-manager> --version'
+var clientVersionRegex = regexp.MustCompile(`\d+\.\d+\.\d+`)
+
+// Create a container manager, which runs the container manager client.
+// The Crane container manager doesn't run a client, and requires the registry credentials. Use NewCraneManager to create it.
 func NewManager(containerManagerType ContainerManagerType) ContainerManager {
 	return &containerManager{Type: containerManagerType}
 }
@@ -34,10 +46,34 @@ type ContainerManagerType int
 const (
 	DockerClient ContainerManagerType = iota
 	Podman
+	Buildah
+	Nerdctl
+	// A pure Go container manager, which works directly against the registry, similarly to the crane tool.
+	Crane
 )
 
+var containerManagerTypes = []string{"docker", "podman", "buildah", "nerdctl", "crane"}
+
 func (cmt ContainerManagerType) String() string {
-	return [...]string{"docker", "podman"}[cmt]
+	return containerManagerTypes[cmt]
+}
+
+// Parse the name of a container manager e.g. podman.
+func ParseContainerManagerType(name string) (ContainerManagerType, error) {
+	for i, containerManagerType := range containerManagerTypes {
+		if strings.EqualFold(name, containerManagerType) {
+			return ContainerManagerType(i), nil
+		}
+	}
+	return DockerClient, errorutils.CheckErrorf("unsupported container manager '%s'. Possible values are: %s", name, strings.Join(containerManagerTypes, ", "))
+}
+
+// Returns the container manager configured by the JFROG_CLI_CONTAINER_MANAGER environment variable, or the default container manager if not configured.
+func GetConfiguredContainerManagerType(defaultType ContainerManagerType) (ContainerManagerType, error) {
+	if name := os.Getenv(coreutils.ContainerManager); name != "" {
+		return ParseContainerManagerType(name)
+	}
+	return defaultType, nil
 }
 
 // Container image
@@ -57,24 +93,54 @@ type ContainerManagerLoginConfig struct {
 	ServerDetails *config.ServerDetails
 }
 
+// Returns an error if the container manager doesn't run a client, and therefore can't run the native commands.
+func (containerManager *containerManager) validateClient() error {
+	if containerManager.Type == Crane {
+		return errorutils.CheckErrorf("running native commands is not supported by the %s container manager. Use NewCraneManager to create it", Crane.String())
+	}
+	return nil
+}
+
+// Returns an error if the container manager doesn't run a client, and therefore has no local images to inspect.
+func (containerManager *containerManager) validateLocalImages() error {
+	if containerManager.Type == Crane {
+		return errorutils.CheckErrorf("inspecting local images is not supported by the %s container manager, which has no local client. Use NewCraneManager to inspect the image in the registry", Crane.String())
+	}
+	return nil
+}
+
 // Run native command of the container buildtool
 func (containerManager *containerManager) RunNativeCmd(cmdParams []string) error {
+	if err := containerManager.validateClient(); err != nil {
+		return err
+	}
 	cmd := &nativeCmd{cmdParams: cmdParams, containerManager: containerManager.Type}
 	return cmd.RunCmd()
 }
 
 // Get image ID
 func (containerManager *containerManager) Id(image *Image) (string, error) {
+	if err := containerManager.validateLocalImages(); err != nil {
+		return "", err
+	}
 	cmd := &getImageIdCmd{image: image, containerManager: containerManager.Type}
 	content, err := cmd.RunCmd()
 	if err != nil {
 		return "", err
 	}
-	return strings.Split(content, "\n")[0], nil
+	id := strings.TrimSpace(strings.Split(content, "\n")[0])
+	// Some container managers, such as Buildah, omit the digest algorithm from the image ID.
+	if id != "" && !strings.Contains(id, ":") {
+		id = "sha256:" + id
+	}
+	return id, nil
 }
 
 // Return the OS and architecture on which the image runs e.g. (linux, amd64, nil).
 func (containerManager *containerManager) OsCompatibility(image *Image) (string, string, error) {
+	if err := containerManager.validateLocalImages(); err != nil {
+		return "", "", err
+	}
 	cmd := &getImageSystemCompatibilityCmd{image: image, containerManager: containerManager.Type}
 	log.Debug("Running image inspect...")
 	content, err := cmd.RunCmd()
@@ -121,9 +187,15 @@ type getImageIdCmd struct {
 
 func (getImageId *getImageIdCmd) GetCmd() *exec.Cmd {
 	var cmd []string
-	cmd = append(cmd, "images")
-	cmd = append(cmd, "--format", "{{.ID}}")
-	cmd = append(cmd, "--no-trunc")
+	if getImageId.containerManager == Nerdctl {
+		// The image ID returned by 'nerdctl images' is always truncated.
+		cmd = append(cmd, "image", "inspect")
+		cmd = append(cmd, "--format", "{{.ID}}")
+	} else {
+		cmd = append(cmd, "images")
+		cmd = append(cmd, "--format", "{{.ID}}")
+		cmd = append(cmd, "--no-trunc")
+	}
 	cmd = append(cmd, getImageId.image.name)
 	return exec.Command(getImageId.containerManager.String(), cmd...)
 }
@@ -145,6 +217,13 @@ type getImageSystemCompatibilityCmd struct {
 
 func (getImageSystemCompatibilityCmd *getImageSystemCompatibilityCmd) GetCmd() *exec.Cmd {
 	var cmd []string
+	if getImageSystemCompatibilityCmd.containerManager == Buildah {
+		cmd = append(cmd, "inspect")
+		cmd = append(cmd, "--type", "image")
+		cmd = append(cmd, "--format", "{{ .OCIv1.OS}},{{ .OCIv1.Architecture}}")
+		cmd = append(cmd, getImageSystemCompatibilityCmd.image.name)
+		return exec.Command(getImageSystemCompatibilityCmd.containerManager.String(), cmd...)
+	}
 	cmd = append(cmd, "image")
 	cmd = append(cmd, "inspect")
 	cmd = append(cmd, getImageSystemCompatibilityCmd.image.name)
@@ -172,7 +251,7 @@ type LoginCmd struct {
 
 func (loginCmd *LoginCmd) GetCmd() *exec.Cmd {
 	if coreutils.IsWindows() {
-		return exec.Command("cmd", "/C", "echo", "%CONTAINER_MANAGER_PASS%|", "docker", "login", loginCmd.DockerRegistry, "--username", loginCmd.Username, "--password-stdin")
+		return exec.Command("cmd", "/C", "echo", "%CONTAINER_MANAGER_PASS%|", loginCmd.containerManager.String(), "login", loginCmd.DockerRegistry, "--username", loginCmd.Username, "--password-stdin")
 	}
 	cmd := "echo $CONTAINER_MANAGER_PASS " + fmt.Sprintf(`| `+loginCmd.containerManager.String()+` login %s --username="%s" --password-stdin`, loginCmd.DockerRegistry, loginCmd.Username)
 	return exec.Command("sh", "-c", cmd)
@@ -190,6 +269,10 @@ func (loginCmd *LoginCmd) RunCmd() error {
 // First we'll try to log in assuming a proxy-less tag (e.g. "registry-address/docker-repo/image:ver").
 // If fails, we will try assuming a reverse proxy tag (e.g. "registry-address-docker-repo/image:ver").
 func ContainerManagerLogin(imageRegistry string, config *ContainerManagerLoginConfig, containerManager ContainerManagerType) error {
+	if containerManager == Crane {
+		// Crane has no credentials store. Verify that the registry accepts the credentials, which are used for each request.
+		return NewCraneManager(config.ServerDetails).Login(imageRegistry)
+	}
 	username := config.ServerDetails.User
 	password := config.ServerDetails.Password
 	// If access-token exists, perform login with it.
@@ -211,7 +294,7 @@ func ContainerManagerLogin(imageRegistry string, config *ContainerManagerLoginCo
 	if indexOfSlash < 0 {
 		return errorutils.CheckErrorf(LoginFailureMessage, containerManager.String(), imageRegistry, containerManager.String())
 	}
-	cmd = &LoginCmd{DockerRegistry: imageRegistry[:indexOfSlash], Username: config.ServerDetails.User, Password: config.ServerDetails.Password}
+	cmd = &LoginCmd{DockerRegistry: imageRegistry[:indexOfSlash], Username: config.ServerDetails.User, Password: config.ServerDetails.Password, containerManager: containerManager}
 	err = cmd.RunCmd()
 	if err != nil {
 		// Login failed for both attempts
@@ -223,10 +306,16 @@ func ContainerManagerLogin(imageRegistry string, config *ContainerManagerLoginCo
 }
 
 // Version command
-// Docker-client provides an API for interacting with the Docker daemon. This cmd should be used for docker client only.
-type VersionCmd struct{}
+// Docker-client provides an API for interacting with the Docker daemon. Therefore, the Docker API version is returned for the docker client,
+// and the client version is returned for the other container managers.
+type VersionCmd struct {
+	containerManager ContainerManagerType
+}
 
 func (versionCmd *VersionCmd) GetCmd() *exec.Cmd {
+	if versionCmd.containerManager != DockerClient {
+		return exec.Command(versionCmd.containerManager.String(), "--version")
+	}
 	var cmd []string
 	cmd = append(cmd, "docker")
 	cmd = append(cmd, "version")
@@ -255,3 +344,24 @@ func ValidateClientApiVersion() error {
 	}
 	return utils.ValidateMinimumVersion(utils.DockerApi, content, MinSupportedApiVersion)
 }
+
+// Validate the version of the container manager client.
+func ValidateClientVersion(containerManager ContainerManagerType) error {
+	switch containerManager {
+	case DockerClient:
+		return ValidateClientApiVersion()
+	case Crane:
+		// Crane doesn't run a client.
+		return nil
+	}
+	cmd := &VersionCmd{containerManager: containerManager}
+	content, err := cmd.RunCmd()
+	if err != nil {
+		return errorutils.CheckErrorf("failed to get the %s client version: %s\n%s", containerManager.String(), err.Error(), content)
+	}
+	clientVersion := clientVersionRegex.FindString(content)
+	if clientVersion == "" {
+		return errorutils.CheckErrorf("failed to get the %s client version. The actual output is: %s", containerManager.String(), content)
+	}
+	return utils.ValidateMinimumVersion(utils.MinVersionProduct(containerManager.String()), clientVersion, minSupportedClientVersions[containerManager])
+}
@@ -0,0 +1,113 @@
+package container
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseContainerManagerType(t *testing.T) {
+	for _, containerManagerType := range []ContainerManagerType{DockerClient, Podman, Buildah, Nerdctl, Crane} {
+		parsed, err := ParseContainerManagerType(strings.ToUpper(containerManagerType.String()))
+		assert.NoError(t, err)
+		assert.Equal(t, containerManagerType, parsed)
+	}
+	_, err := ParseContainerManagerType("kaniko")
+	assert.ErrorContains(t, err, "unsupported container manager 'kaniko'")
+}
+
+func TestGetConfiguredContainerManagerType(t *testing.T) {
+	containerManagerType, err := GetConfiguredContainerManagerType(Podman)
+	assert.NoError(t, err)
+	assert.Equal(t, Podman, containerManagerType)
+
+	t.Setenv(coreutils.ContainerManager, "nerdctl")
+	containerManagerType, err = GetConfiguredContainerManagerType(Podman)
+	assert.NoError(t, err)
+	assert.Equal(t, Nerdctl, containerManagerType)
+	assert.Equal(t, Nerdctl, NewManager(containerManagerType).GetContainerManagerType())
+}
+
+func TestContainerManagerCommands(t *testing.T) {
+	image := NewImage("my-registry.io/docker-local/hello-world:1.0")
+	var testCases = []struct {
+		containerManager ContainerManagerType
+		expectedIdCmd    string
+		expectedOsCmd    string
+	}{
+		{DockerClient, "docker images --format {{.ID}} --no-trunc " + image.Name(), "docker image inspect " + image.Name() + " --format {{ .Os}},{{ .Architecture}}"},
+		{Podman, "podman images --format {{.ID}} --no-trunc " + image.Name(), "podman image inspect " + image.Name() + " --format {{ .Os}},{{ .Architecture}}"},
+		{Buildah, "buildah images --format {{.ID}} --no-trunc " + image.Name(), "buildah inspect --type image --format {{ .OCIv1.OS}},{{ .OCIv1.Architecture}} " + image.Name()},
+		{Nerdctl, "nerdctl image inspect --format {{.ID}} " + image.Name(), "nerdctl image inspect " + image.Name() + " --format {{ .Os}},{{ .Architecture}}"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.containerManager.String(), func(t *testing.T) {
+			idCmd := (&getImageIdCmd{image: image, containerManager: testCase.containerManager}).GetCmd()
+			assert.Equal(t, testCase.expectedIdCmd, strings.Join(idCmd.Args, " "))
+			osCmd := (&getImageSystemCompatibilityCmd{image: image, containerManager: testCase.containerManager}).GetCmd()
+			assert.Equal(t, testCase.expectedOsCmd, strings.Join(osCmd.Args, " "))
+		})
+	}
+	assert.Equal(t, []string{"buildah", "--version"}, (&VersionCmd{containerManager: Buildah}).GetCmd().Args)
+	assert.Equal(t, []string{"docker", "version", "--format", "{{.Client.APIVersion}}"}, (&VersionCmd{}).GetCmd().Args)
+}
+
+func TestCraneManager(t *testing.T) {
+	registry, serverDetails := newTestRegistryServer(t)
+	amdDigest, _ := registry.addImage(t, "docker-local/hello-world", "amd64", "1.0")
+	armDigest, _ := registry.addImage(t, "docker-local/hello-world", "arm64")
+	registry.addManifest(t, "docker-local/hello-world", ociIndexMediaType, FatManifest{
+		MediaType: ociIndexMediaType,
+		Manifests: []ManifestDetails{
+			{Digest: amdDigest, Platform: Platform{Os: "linux", Architecture: "amd64"}},
+			{Digest: armDigest, Platform: Platform{Os: "linux", Architecture: "arm64"}},
+		},
+	}, "multi-platform")
+	registryHost := strings.TrimSuffix(strings.TrimPrefix(serverDetails.ArtifactoryUrl, "http://"), "/")
+	craneManager := NewCraneManager(serverDetails)
+
+	// Single-platform image
+	image := NewImage(registryHost + "/docker-local/hello-world:1.0")
+	id, err := craneManager.Id(image)
+	assert.NoError(t, err)
+	assert.Equal(t, getTestImageConfigDigest(t, registry, "docker-local/hello-world/"+amdDigest), id)
+	imageOs, imageArch, err := craneManager.OsCompatibility(image)
+	assert.NoError(t, err)
+	assert.Equal(t, "linux", imageOs)
+	assert.Equal(t, "amd64", imageArch)
+
+	// Multi-platform image
+	image = NewImage(registryHost + "/docker-local/hello-world:multi-platform")
+	imageOs, imageArch, err = craneManager.SetPlatform("linux", "arm64").OsCompatibility(image)
+	assert.NoError(t, err)
+	assert.Equal(t, "linux", imageOs)
+	assert.Equal(t, "arm64", imageArch)
+	_, err = craneManager.SetPlatform("windows", "amd64").Id(image)
+	assert.ErrorContains(t, err, "has no manifest for the platform windows/amd64")
+
+	// Login
+	assert.NoError(t, ContainerManagerLogin(registryHost+"/docker-local", &ContainerManagerLoginConfig{ServerDetails: serverDetails}, Crane))
+	wrongCredentials := &config.ServerDetails{ArtifactoryUrl: serverDetails.ArtifactoryUrl, User: testRegistryUser, Password: "wrong"}
+	assert.ErrorContains(t, ContainerManagerLogin(registryHost, &ContainerManagerLoginConfig{ServerDetails: wrongCredentials}, Crane), "crane login failed")
+
+	assert.Error(t, craneManager.RunNativeCmd([]string{"push", image.Name()}))
+	assert.NoError(t, ValidateClientVersion(Crane))
+
+	// A Crane container manager without credentials can't be created by NewManager
+	nativeManager := NewManager(Crane)
+	assert.ErrorContains(t, nativeManager.RunNativeCmd([]string{"push", image.Name()}), "running native commands is not supported by the crane container manager")
+	_, err = nativeManager.Id(image)
+	assert.ErrorContains(t, err, "inspecting local images is not supported by the crane container manager, which has no local client")
+	_, _, err = nativeManager.OsCompatibility(image)
+	assert.ErrorContains(t, err, "inspecting local images is not supported by the crane container manager, which has no local client")
+}
+
+func getTestImageConfigDigest(t *testing.T, registry *testRegistry, manifestKey string) string {
+	var imageManifest manifest
+	assert.NoError(t, json.Unmarshal(registry.manifests[manifestKey].Content, &imageManifest))
+	return imageManifest.Config.Digest
+}
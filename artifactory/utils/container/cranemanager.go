@@ -0,0 +1,142 @@
+package container
+
+import (
+	"encoding/json"
+	"runtime"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Container manager, which works directly against the registry in pure Go, similarly to the crane tool.
+// It doesn't require a container manager client or a daemon. The image details are read from the registry rather than from a local image store.
+type CraneManager struct {
+	// The credentials for the registry. If nil, the registry is accessed anonymously.
+	serverDetails *config.ServerDetails
+	// The platform to use, when the image is a multi-platform image.
+	platform Platform
+}
+
+func NewCraneManager(serverDetails *config.ServerDetails) *CraneManager {
+	return &CraneManager{serverDetails: serverDetails, platform: Platform{Os: "linux", Architecture: runtime.GOARCH}}
+}
+
+// Set the platform to use, when the image is a multi-platform image. The default is linux and the current architecture.
+func (cm *CraneManager) SetPlatform(os, architecture string) *CraneManager {
+	cm.platform = Platform{Os: os, Architecture: architecture}
+	return cm
+}
+
+// Returns the image ID, which is the digest of the image config.
+func (cm *CraneManager) Id(image *Image) (string, error) {
+	imageManifest, _, err := cm.getImageManifest(image)
+	if err != nil {
+		return "", err
+	}
+	return imageManifest.Config.Digest, nil
+}
+
+// Return the OS and architecture on which the image runs e.g. (linux, amd64, nil).
+func (cm *CraneManager) OsCompatibility(image *Image) (string, string, error) {
+	imageManifest, registryClient, err := cm.getImageManifest(image)
+	if err != nil {
+		return "", "", err
+	}
+	reference, err := image.Reference()
+	if err != nil {
+		return "", "", err
+	}
+	var imageConfig struct {
+		Os           string `json:"os"`
+		Architecture string `json:"architecture"`
+	}
+	if err = registryClient.GetJsonBlob(reference.Path(), imageManifest.Config.Digest, &imageConfig); err != nil {
+		return "", "", err
+	}
+	if imageConfig.Os == "" || imageConfig.Architecture == "" {
+		return "", "", errorutils.CheckErrorf("couldn't find OS and architecture of image:" + image.name)
+	}
+	return imageConfig.Os, imageConfig.Architecture, nil
+}
+
+// Crane doesn't run a container manager client, and therefore can't run native commands.
+func (cm *CraneManager) RunNativeCmd(cmdParams []string) error {
+	return errorutils.CheckErrorf("running native commands is not supported by the %s container manager: %s", Crane.String(), strings.Join(cmdParams, " "))
+}
+
+func (cm *CraneManager) GetContainerManagerType() ContainerManagerType {
+	return Crane
+}
+
+// Verify that the registry accepts the credentials. The registry may be followed by a repository path e.g. my-registry.io/docker-local.
+func (cm *CraneManager) Login(imageRegistry string) error {
+	registry := strings.Split(imageRegistry, "/")[0]
+	registryClient, err := cm.createRegistryClient(registry)
+	if err != nil {
+		return err
+	}
+	if err = registryClient.Ping(); err != nil {
+		return errorutils.CheckErrorf(LoginFailureMessage, Crane.String(), imageRegistry, Crane.String()+" "+err.Error())
+	}
+	return nil
+}
+
+// Returns the manifest of the image. For multi-platform images, the manifest of the configured platform is returned.
+func (cm *CraneManager) getImageManifest(image *Image) (*manifest, *RegistryClient, error) {
+	reference, err := image.Reference()
+	if err != nil {
+		return nil, nil, err
+	}
+	if reference.Registry == "" {
+		return nil, nil, errorutils.CheckErrorf("the image '%s' must include the registry when using the %s container manager", image.Name(), Crane.String())
+	}
+	registryClient, err := cm.createRegistryClient(reference.Registry)
+	if err != nil {
+		return nil, nil, err
+	}
+	registryManifest, err := registryClient.GetManifest(reference.Path(), reference.ManifestReference())
+	if err != nil {
+		return nil, nil, err
+	}
+	if registryManifest.IsIndex() {
+		var index FatManifest
+		if err = json.Unmarshal(registryManifest.Content, &index); err != nil {
+			return nil, nil, errorutils.CheckError(err)
+		}
+		digest := searchManifestDigest(cm.platform.Os, cm.platform.Architecture, index.Manifests)
+		if digest == "" {
+			return nil, nil, errorutils.CheckErrorf("the image '%s' has no manifest for the platform %s/%s", image.Name(), cm.platform.Os, cm.platform.Architecture)
+		}
+		log.Debug("Found the manifest " + digest + " for the platform " + cm.platform.Os + "/" + cm.platform.Architecture)
+		if registryManifest, err = registryClient.GetManifest(reference.Path(), digest); err != nil {
+			return nil, nil, err
+		}
+	}
+	imageManifest := new(manifest)
+	return imageManifest, registryClient, errorutils.CheckError(json.Unmarshal(registryManifest.Content, imageManifest))
+}
+
+func (cm *CraneManager) createRegistryClient(registry string) (*RegistryClient, error) {
+	httpDetails := httputils.HttpClientDetails{}
+	builder := jfroghttpclient.JfrogClientBuilder()
+	// Use TLS, unless Artifactory is accessed without it.
+	scheme := "https://"
+	if cm.serverDetails != nil {
+		httpDetails.User = cm.serverDetails.User
+		httpDetails.Password = cm.serverDetails.Password
+		httpDetails.AccessToken = cm.serverDetails.AccessToken
+		builder.SetInsecureTls(cm.serverDetails.InsecureTls)
+		if strings.HasPrefix(cm.serverDetails.ArtifactoryUrl, "http://") {
+			scheme = "http://"
+		}
+	}
+	client, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	return NewRegistryClient(scheme+registry, client, httpDetails), nil
+}
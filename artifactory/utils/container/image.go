@@ -14,6 +14,8 @@ import (
 type Image struct {
 	// Image name includes the registry domain, image base name and image tag e.g.: my-registry:port/docker-local/hello-world:latest.
 	name string
+	// The parsed image reference. Initialized on the first call to Reference().
+	reference *Reference
 }
 
 func NewImage(imageTag string) *Image {
@@ -25,119 +27,121 @@ func (image *Image) Name() string {
 	return image.name
 }
 
-// Get image name from tag by removing the prefixed registry hostname.
-// e.g.: https://my-registry/docker-local/hello-world:latest. -> docker-local/hello-world:latest
-func (image *Image) GetImageLongNameWithTag() (string, error) {
-	if err := image.validateTag(); err != nil {
-		return "", err
+// Reference returns the parsed image reference.
+// If the image includes neither a tag nor a digest, the 'latest' tag is added to the image name.
+func (image *Image) Reference() (*Reference, error) {
+	if image.reference != nil {
+		return image.reference, nil
 	}
-	indexOfLastSlash := strings.Index(image.name, "/")
-	indexOfLastColon := strings.LastIndex(image.name, ":")
-	if indexOfLastColon < 0 || indexOfLastColon < indexOfLastSlash {
+	reference, err := ParseReference(image.name)
+	if err != nil {
+		return nil, err
+	}
+	if reference.Tag == "" && reference.Digest == "" {
 		log.Info("The image '" + image.name + "' does not include tag. Using the 'latest' tag.")
-		image.name += ":latest"
+		reference.Tag = defaultImageTag
+		image.name += ":" + defaultImageTag
 	}
-	return image.name[indexOfLastSlash+1:], nil
+	image.reference = reference
+	return reference, nil
 }
 
-// Get image base name by removing the prefixed registry hostname and the tag.
-// e.g.: https://my-registry/docker-local/hello-world:latest. -> docker-local/hello-world
-func (image *Image) GetImageLongName() (string, error) {
-	imageName, err := image.GetImageLongNameWithTag()
+// Get image name from tag by removing the prefixed registry hostname.
+// e.g.: my-registry/docker-local/hello-world:latest -> docker-local/hello-world:latest
+// e.g.: my-registry/docker-local/hello-world@sha256:<hex> -> docker-local/hello-world@sha256:<hex>
+func (image *Image) GetImageLongNameWithTag() (string, error) {
+	reference, err := image.Reference()
 	if err != nil {
 		return "", err
 	}
-	tagIndex := strings.Index(imageName, ":")
-	return imageName[:tagIndex], nil
+	return reference.withTagAndDigest(reference.Path()), nil
 }
 
-func (image *Image) validateTag() error {
-	if !strings.Contains(image.name, "/") {
-		return errorutils.CheckErrorf("The image '%s' is missing '/' which indicates the image name/tag", image.name)
+// Get image base name by removing the prefixed registry hostname and the tag.
+// e.g.: my-registry/docker-local/hello-world:latest -> docker-local/hello-world
+func (image *Image) GetImageLongName() (string, error) {
+	reference, err := image.Reference()
+	if err != nil {
+		return "", err
 	}
-	return nil
+	return reference.Path(), nil
 }
 
 // Get image base name by removing the prefixed registry hostname and the tag.
-// e.g.: https://my-registry/docker-local/hello-world:latest. -> hello-world
+// e.g.: my-registry/docker-local/hello-world:latest -> hello-world
 func (image *Image) GetImageShortName() (string, error) {
-	imageName, err := image.GetImageShortNameWithTag()
+	reference, err := image.Reference()
 	if err != nil {
 		return "", err
 	}
-	tagIndex := strings.LastIndex(imageName, ":")
-	if tagIndex != -1 {
-		return imageName[:tagIndex], nil
-	}
-	return imageName, nil
+	return reference.Repository, nil
 }
 
 // Get image base name by removing the prefixed registry hostname.
-// e.g.: https://my-registry/docker-local/hello-world:latest. -> hello-world:latest
+// e.g.: my-registry/docker-local/hello-world:latest -> hello-world:latest
 func (image *Image) GetImageShortNameWithTag() (string, error) {
-	imageName, err := image.GetImageLongNameWithTag()
+	reference, err := image.Reference()
 	if err != nil {
 		return "", err
 	}
-	indexOfSlash := strings.LastIndex(imageName, "/")
-	if indexOfSlash != -1 {
-		return imageName[indexOfSlash+1:], nil
-
-	}
-	return imageName, nil
+	return reference.withTagAndDigest(reference.Repository), nil
 }
 
 // GetImageLongNameWithoutRepoWithTag removes the registry hostname and repository name, returning the organization and image name with the tag.
 // e.g., "docker-local/myorg/hello-world:latest" -> "myorg/hello-world:latest"
 // e.g., "docker-local/hello-world:latest" -> "hello-world:latest"
 func (image *Image) GetImageLongNameWithoutRepoWithTag() (string, error) {
-	longName, err := image.GetImageLongNameWithTag()
+	reference, err := image.Reference()
 	if err != nil {
 		return "", err
 	}
-	parts := strings.Split(longName, "/")
+	parts := strings.Split(reference.Path(), "/")
 	if len(parts) > 1 {
-		return strings.Join(parts[1:], "/"), nil
+		parts = parts[1:]
 	}
-	return longName, nil
+	return reference.withTagAndDigest(strings.Join(parts, "/")), nil
 }
 
 // Get image tag name of an image.
-// e.g.: https://my-registry/docker-local/hello-world:latest. -> latest
+// e.g.: my-registry/docker-local/hello-world:latest -> latest
 func (image *Image) GetImageTag() (string, error) {
-	imageName, err := image.GetImageLongNameWithTag()
+	reference, err := image.Reference()
 	if err != nil {
 		return "", err
 	}
-	tagIndex := strings.Index(imageName, ":")
-	if tagIndex == -1 {
+	if reference.Tag == "" {
 		return "", errorutils.CheckErrorf("unexpected image name '%s'. Failed to get image tag.", image.Name())
 	}
-	return imageName[tagIndex+1:], nil
+	return reference.Tag, nil
+}
+
+// Get image digest of an image, or an empty string if the image is referenced by tag only.
+// e.g.: my-registry/docker-local/hello-world@sha256:<hex> -> sha256:<hex>
+func (image *Image) GetImageDigest() (string, error) {
+	reference, err := image.Reference()
+	if err != nil {
+		return "", err
+	}
+	return reference.Digest, nil
 }
 
 func (image *Image) GetRegistry() (string, error) {
-	if err := image.validateTag(); err != nil {
+	reference, err := image.Reference()
+	if err != nil {
 		return "", err
 	}
-	indexOfLastSlash := strings.Index(image.name, "/")
-	if indexOfLastSlash == -1 {
+	if reference.Registry == "" {
 		return "", errorutils.CheckErrorf("unexpected image name '%s'. Failed to get registry.", image.Name())
 	}
-	return image.name[:indexOfLastSlash], nil
+	return reference.Registry, nil
 }
 
 // Returns the physical Artifactory repository name of the pulled/pushed image, by reading a response header from Artifactory.
 func (image *Image) GetRemoteRepo(serviceManager artifactory.ArtifactoryServicesManager) (string, error) {
-	containerRegistryUrl, err := image.GetRegistry()
-	if err != nil {
-		return "", err
-	}
-	longImageName, err := image.GetImageLongName()
-	if err != nil {
+	if _, err := image.GetRegistry(); err != nil {
 		return "", err
 	}
-	imageTag, err := image.GetImageTag()
+	reference, err := image.Reference()
 	if err != nil {
 		return "", err
 	}
@@ -146,7 +150,7 @@ func (image *Image) GetRemoteRepo(serviceManager artifactory.ArtifactoryServices
 		isSecure = true
 	}
 	// Build the request URL.
-	endpoint := buildRequestUrl(longImageName, imageTag, containerRegistryUrl, isSecure)
+	endpoint := buildRequestUrl(reference, isSecure)
 	artHttpDetails := serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
 	artHttpDetails.Headers["accept"] = "application/vnd.docker.distribution.manifest.v1+prettyjws, application/json, application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json, application/vnd.docker.distribution.manifest.list.v2+json, application/vnd.oci.image.index.v1+json"
 	resp, _, err := serviceManager.Client().SendHead(endpoint, &artHttpDetails)
@@ -165,9 +169,9 @@ func (image *Image) GetRemoteRepo(serviceManager artifactory.ArtifactoryServices
 	return "", errorutils.CheckErrorf("couldn't find 'X-Artifactory-Docker-Registry' header  docker repository in artifactory")
 }
 
-// Returns the name of the repository containing the image in Artifactory.
-func buildRequestUrl(longImageName, imageTag, containerRegistryUrl string, https bool) string {
-	endpoint := path.Join(containerRegistryUrl, "v2", longImageName, "manifests", imageTag)
+// Returns the URL of the image manifest in the registry. The digest is preferred over the tag, since it pins the manifest.
+func buildRequestUrl(reference *Reference, https bool) string {
+	endpoint := path.Join(reference.Registry, "v2", reference.Path(), "manifests", reference.ManifestReference())
 	if https {
 		return "https://" + endpoint
 	}
@@ -1,11 +1,14 @@
 package container
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+const testDigestHex = "30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e"
+
 func TestGetImageLongName(t *testing.T) {
 	var imageTags = []struct {
 		in       string
@@ -17,6 +20,9 @@ func TestGetImageLongName(t *testing.T) {
 		{"domain/path:1.0", "path"},
 		{"domain/path/in/artifactory:1.0", "path/in/artifactory"},
 		{"domain/path/in/artifactory", "path/in/artifactory"},
+		{"localhost:8082/path/in/artifactory@sha256:" + testDigestHex, "path/in/artifactory"},
+		{"hello-world:1.0", "hello-world"},
+		{"hello-world", "hello-world"},
 	}
 
 	for _, v := range imageTags {
@@ -26,8 +32,8 @@ func TestGetImageLongName(t *testing.T) {
 			t.Errorf("GetImageLongName(\"%s\") => '%s', want '%s'", v.in, result, v.expected)
 		}
 	}
-	// Validate failure upon missing image name
-	_, err := NewImage("domain").GetImageLongName()
+	// Validate failure upon an invalid image name
+	_, err := NewImage("domain/Path").GetImageLongName()
 	assert.Error(t, err)
 
 }
@@ -43,6 +49,7 @@ func TestGetImageShortName(t *testing.T) {
 		{"domain/path:1.0", "path"},
 		{"domain/path/in/artifactory:1.0", "artifactory"},
 		{"domain/path/in/artifactory", "artifactory"},
+		{"domain", "domain"},
 	}
 
 	for _, v := range imageTags {
@@ -52,8 +59,8 @@ func TestGetImageShortName(t *testing.T) {
 			t.Errorf("GetImageShortName(\"%s\") => '%s', want '%s'", v.in, result, v.expected)
 		}
 	}
-	// Validate failure upon missing image name
-	_, err := NewImage("domain").GetImageShortName()
+	// Validate failure upon an invalid image name
+	_, err := NewImage("domain/path:").GetImageShortName()
 	assert.Error(t, err)
 
 }
@@ -69,6 +76,9 @@ func TestGetImageLongNameWithTag(t *testing.T) {
 		{"domain/path:1.0", "path:1.0"},
 		{"domain/path/in/artifactory:1.0", "path/in/artifactory:1.0"},
 		{"domain/path/in/artifactory", "path/in/artifactory:latest"},
+		{"domain:8080/path@sha256:" + testDigestHex, "path@sha256:" + testDigestHex},
+		{"domain:8080/path:1.0@sha256:" + testDigestHex, "path:1.0@sha256:" + testDigestHex},
+		{"domain", "domain:latest"},
 	}
 
 	for _, v := range imageTags {
@@ -78,8 +88,8 @@ func TestGetImageLongNameWithTag(t *testing.T) {
 			t.Errorf("GetImageLongNameWithTag(\"%s\") => '%s', want '%s'", v.in, result, v.expected)
 		}
 	}
-	// Validate failure upon missing image name
-	_, err := NewImage("domain").GetImageLongNameWithTag()
+	// Validate failure upon an invalid digest
+	_, err := NewImage("domain/path@sha256:123").GetImageLongNameWithTag()
 	assert.Error(t, err)
 }
 
@@ -92,6 +102,7 @@ func TestGetImageLongNameWithoutRepoWithTag(t *testing.T) {
 		{"domain/repo-name/hello-world:latest", "hello-world:latest"},
 		{"domain/repo-name/org-name/hello-world:latest", "org-name/hello-world:latest"},
 		{"domain/repo-name/org-name/hello-world", "org-name/hello-world:latest"},
+		{"hello-world", "hello-world:latest"},
 	}
 
 	for _, v := range imageTags {
@@ -99,8 +110,8 @@ func TestGetImageLongNameWithoutRepoWithTag(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, v.expected, result)
 	}
-	// Validate failure upon missing image name
-	_, err := NewImage("domain").GetImageLongNameWithoutRepoWithTag()
+	// Validate failure upon an invalid image name
+	_, err := NewImage("domain/repo-name//hello-world").GetImageLongNameWithoutRepoWithTag()
 	assert.Error(t, err)
 }
 
@@ -115,6 +126,7 @@ func TestGetImageShortNameWithTag(t *testing.T) {
 		{"domain/path:1.0", "path:1.0"},
 		{"domain/path/in/artifactory:1.0", "artifactory:1.0"},
 		{"domain/path/in/artifactory", "artifactory:latest"},
+		{"domain/path/in/artifactory@sha256:" + testDigestHex, "artifactory@sha256:" + testDigestHex},
 	}
 
 	for _, v := range imageTags {
@@ -124,8 +136,8 @@ func TestGetImageShortNameWithTag(t *testing.T) {
 			t.Errorf("GetImageShortNameWithTag(\"%s\") => '%s', want '%s'", v.in, result, v.expected)
 		}
 	}
-	// Validate failure upon missing image name
-	_, err := NewImage("domain").GetImageLongNameWithTag()
+	// Validate failure upon an invalid image name
+	_, err := NewImage("domain/path/in/artifactory:").GetImageShortNameWithTag()
 	assert.Error(t, err)
 }
 
@@ -141,7 +153,10 @@ func TestResolveRegistryFromTag(t *testing.T) {
 		{"domain/path:1.0", "domain", false},
 		{"domain/path/in/artifactory:1.0", "domain", false},
 		{"domain/path/in/artifactory", "domain", false},
+		{"localhost/path/in/artifactory@sha256:" + testDigestHex, "localhost", false},
+		{"[::1]:5000/path:1.0", "[::1]:5000", false},
 		{"domain:8081", "", true},
+		{"domain", "", true},
 	}
 
 	for _, v := range imageTags {
@@ -190,19 +205,56 @@ func TestBuildRemoteRepoUrl(t *testing.T) {
 		{"jfrog-docker-local.jfrog.io/hello-world:123", true, "https://jfrog-docker-local.jfrog.io/v2/hello-world/manifests/123"},
 		{"jfrog-docker-local.jfrog.io/hello-world:latest", true, "https://jfrog-docker-local.jfrog.io/v2/hello-world/manifests/latest"},
 		{"jfrog-docker-local.jfrog.io/hello-world:123", false, "http://jfrog-docker-local.jfrog.io/v2/hello-world/manifests/123"},
+		// With digest
+		{"localhost:8082/docker-local/hello-world@sha256:" + testDigestHex, true, "https://localhost:8082/v2/docker-local/hello-world/manifests/sha256:" + testDigestHex},
+		{"localhost:8082/docker-local/hello-world:123@sha256:" + testDigestHex, true, "https://localhost:8082/v2/docker-local/hello-world/manifests/sha256:" + testDigestHex},
 	}
 	for _, v := range data {
-		testImae := NewImage(v.image)
-		containerRegistryUrl, err := testImae.GetRegistry()
-		assert.NoError(t, err)
-
-		longImageName, err := testImae.GetImageLongName()
-		assert.NoError(t, err)
+		reference, err := NewImage(v.image).Reference()
+		if assert.NoError(t, err) {
+			assert.Equal(t, v.expectedRepo, buildRequestUrl(reference, v.isSecure))
+		}
+	}
+}
 
-		imageTag, err := testImae.GetImageTag()
-		assert.NoError(t, err)
+func TestParseReference(t *testing.T) {
+	var references = []struct {
+		in       string
+		expected Reference
+	}{
+		{"hello-world", Reference{Repository: "hello-world"}},
+		{"hello-world:1.0", Reference{Repository: "hello-world", Tag: "1.0"}},
+		{"localhost/hello-world", Reference{Registry: "localhost", Repository: "hello-world"}},
+		{"my-registry.io:8082/docker-local/org/hello_world:v1.0-rc", Reference{Registry: "my-registry.io:8082", Namespace: "docker-local/org", Repository: "hello_world", Tag: "v1.0-rc"}},
+		{"my-registry.io/docker-local/hello-world@sha256:" + testDigestHex, Reference{Registry: "my-registry.io", Namespace: "docker-local", Repository: "hello-world", Digest: "sha256:" + testDigestHex}},
+		{"my-registry.io/docker-local/hello-world:1.0@sha256:" + testDigestHex, Reference{Registry: "my-registry.io", Namespace: "docker-local", Repository: "hello-world", Tag: "1.0", Digest: "sha256:" + testDigestHex}},
+		{"[::1]:5000/hello-world", Reference{Registry: "[::1]:5000", Repository: "hello-world"}},
+	}
+	for _, v := range references {
+		t.Run(v.in, func(t *testing.T) {
+			reference, err := ParseReference(v.in)
+			if assert.NoError(t, err) {
+				assert.Equal(t, v.expected, *reference)
+				assert.Equal(t, v.in, reference.String())
+			}
+		})
+	}
 
-		actualRepo := buildRequestUrl(longImageName, imageTag, containerRegistryUrl, v.isSecure)
-		assert.Equal(t, v.expectedRepo, actualRepo)
+	var invalidReferences = []string{
+		"",
+		"Hello-World",
+		"my-registry.io/docker-local/",
+		"my-registry.io//hello-world",
+		"my_registry.io/hello-world",
+		"my-registry.io/hello-world:",
+		"my-registry.io/hello-world:-tag",
+		"my-registry.io/hello-world@sha256:123",
+		"my-registry.io/hello-world@sha256:" + strings.ToUpper(testDigestHex),
+		"my-registry.io/hello-world@" + testDigestHex,
+		"my-registry.io/" + strings.Repeat("a", maxReferenceNameLength),
+	}
+	for _, in := range invalidReferences {
+		_, err := ParseReference(in)
+		assert.Error(t, err, in)
 	}
 }
@@ -39,6 +39,13 @@ func NewLocalAgentBuildInfoBuilder(image *Image, repository, buildName, buildNum
 	}, err
 }
 
+// Set the base image of the pushed image, and the repository in which it's stored. If the repository is empty, the image repository is used.
+// If not set, the base image is taken from the image manifest annotations, if exist.
+func (labib *localAgentBuildInfoBuilder) SetBaseImage(baseImage *Image, repo string) *localAgentBuildInfoBuilder {
+	labib.buildInfoBuilder.setBaseImage(baseImage, repo)
+	return labib
+}
+
 func (labib *localAgentBuildInfoBuilder) GetLayers() *[]utils.ResultItem {
 	return &labib.buildInfoBuilder.imageLayers
 }
@@ -64,12 +71,11 @@ func (labib *localAgentBuildInfoBuilder) Build(module string) (*buildinfo.BuildI
 
 // Search an image in Artifactory and validate its sha2 with local image.
 func (labib *localAgentBuildInfoBuilder) searchImage() (map[string]*utils.ResultItem, *manifest, error) {
-	longImageName, err := labib.buildInfoBuilder.image.GetImageLongNameWithTag()
+	reference, err := labib.buildInfoBuilder.image.Reference()
 	if err != nil {
 		return nil, nil, err
 	}
-	imagePath := strings.Replace(longImageName, ":", "/", 1)
-	manifestPathsCandidates := getManifestPaths(imagePath, labib.buildInfoBuilder.getSearchableRepo(), labib.commandType)
+	manifestPathsCandidates := getManifestPaths(reference, labib.buildInfoBuilder.getSearchableRepo(), labib.commandType)
 	log.Debug("Start searching for image manifest.json")
 	for _, path := range manifestPathsCandidates {
 		log.Debug(`Searching in:"` + path + `"`)
@@ -10,7 +10,9 @@ import (
 
 // To unmarshal config layer file
 type configLayer struct {
-	History []history `json:"history,omitempty"`
+	Os           string    `json:"os,omitempty"`
+	Architecture string    `json:"architecture,omitempty"`
+	History      []history `json:"history,omitempty"`
 }
 
 type history struct {
@@ -21,25 +23,53 @@ type history struct {
 
 // To unmarshal manifest.json file
 type manifest struct {
-	Config manifestConfig `json:"config,omitempty"`
-	Layers []layer        `json:"layers,omitempty"`
+	SchemaVersion int    `json:"schemaVersion,omitempty"`
+	MediaType     string `json:"mediaType,omitempty"`
+	// The type of OCI artifacts, such as signatures, SBOMs and Helm charts. Empty for images.
+	ArtifactType string         `json:"artifactType,omitempty"`
+	Config       manifestConfig `json:"config,omitempty"`
+	Layers       []layer        `json:"layers,omitempty"`
+	// The manifest to which an OCI artifact is attached.
+	Subject     *descriptor       `json:"subject,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// An OCI content descriptor, which references a manifest or a blob.
+type descriptor struct {
+	MediaType    string            `json:"mediaType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size,omitempty"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// To unmarshal the response of the referrers API, which is an image index of the artifacts attached to a manifest.
+type referrersIndex struct {
+	MediaType string       `json:"mediaType,omitempty"`
+	Manifests []descriptor `json:"manifests"`
 }
 
 type manifestConfig struct {
-	Digest string `json:"digest,omitempty"`
+	Digest    string `json:"digest,omitempty"`
+	MediaType string `json:"mediaType,omitempty"`
+	Size      int64  `json:"size,omitempty"`
 }
 
 type layer struct {
 	Digest    string `json:"digest,omitempty"`
 	MediaType string `json:"mediaType,omitempty"`
+	Size      int64  `json:"size,omitempty"`
 }
 
 type FatManifest struct {
+	MediaType string            `json:"mediaType,omitempty"`
 	Manifests []ManifestDetails `json:"manifests"`
 }
 
 type ManifestDetails struct {
 	Digest      string      `json:"digest"`
+	MediaType   string      `json:"mediaType,omitempty"`
+	Size        int64       `json:"size,omitempty"`
 	Platform    Platform    `json:"platform"`
 	Annotations Annotations `json:"annotations"`
 }
@@ -56,19 +86,25 @@ type Annotations struct {
 }
 
 // Return all the search patterns in which manifest can be found.
-func getManifestPaths(imagePath, repo string, commandType CommandType) []string {
+func getManifestPaths(reference *Reference, repo string, commandType CommandType) []string {
+	manifestFolder := reference.manifestFolder()
+	imagePath := path.Join(reference.Path(), manifestFolder)
 	// pattern 1: reverse proxy e.g. ecosysjfrog-docker-local.jfrog.io.
 	paths := []string{path.Join(repo, imagePath, "*")}
-	// pattern 2: proxy-less e.g. orgab.jfrog.team/docker-local.
-	endOfRepoNameIndex := strings.Index(imagePath[1:], "/")
-	proxylessTag := imagePath[endOfRepoNameIndex+1:]
-	paths = append(paths, path.Join(repo, proxylessTag, "*"))
+	// pattern 2: proxy-less e.g. orgab.jfrog.team/docker-local. The first component of the path is the repository name.
+	proxylessPath := imagePath
+	if reference.Namespace != "" {
+		proxylessPath = path.Join(strings.SplitN(reference.Path(), "/", 2)[1], manifestFolder)
+		paths = append(paths, path.Join(repo, proxylessPath, "*"))
+	}
 	// If image path includes more than 3 slashes, Artifactory doesn't store this image under 'library', thus we should not look further.
 	if commandType != Push && strings.Count(imagePath, "/") <= 3 {
 		// pattern 3: reverse proxy - this time with 'library' as part of the path.
 		paths = append(paths, path.Join(repo, "library", imagePath, "*"))
 		// pattern 4: Assume proxy-less - this time with 'library' as part of the path.
-		paths = append(paths, path.Join(repo, "library", proxylessTag, "*"))
+		if reference.Namespace != "" {
+			paths = append(paths, path.Join(repo, "library", proxylessPath, "*"))
+		}
 	}
 	return paths
 }
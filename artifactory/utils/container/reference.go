@@ -0,0 +1,134 @@
+package container
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The grammar of image references, according to the OCI distribution spec:
+// reference := name [ ":" tag ] [ "@" digest ]
+// name      := [registry "/"] path-component ["/" path-component]*
+var (
+	// A registry host (domain name, IPv4 or bracketed IPv6 address) with an optional port e.g. my-registry.io:8082, localhost, [::1]:5000.
+	registryRegexp = regexp.MustCompile(`^(([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(\.([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*|\[[a-fA-F0-9:]+])(:[0-9]+)?$`)
+	// A single component of the repository path e.g. docker-local, hello_world.
+	pathComponentRegexp = regexp.MustCompile(`^[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*$`)
+	tagRegexp           = regexp.MustCompile(`^\w[\w.-]{0,127}$`)
+	digestRegexp        = regexp.MustCompile(`^[a-z0-9]+([+._-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
+	// The digests of the registered algorithms must be encoded as lowercase hex of a fixed length.
+	digestAlgorithmsHexLength = map[string]int{"sha256": 64, "sha512": 128}
+)
+
+const (
+	maxReferenceNameLength = 255
+	defaultImageTag        = "latest"
+)
+
+// Reference is a parsed image reference e.g. my-registry:8082/docker-local/org/hello-world:1.0@sha256:<hex>.
+// Unlike the Docker client, the first component of a name with more than one component is always the registry,
+// since the images handled by the CLI are pushed to or pulled from an Artifactory registry.
+type Reference struct {
+	// The registry host and port e.g. my-registry:8082. Empty if the name has a single component.
+	Registry string
+	// The path of the repository without its last component e.g. docker-local/org.
+	Namespace string
+	// The last component of the repository path e.g. hello-world.
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses an image reference according to the OCI distribution spec.
+func ParseReference(reference string) (*Reference, error) {
+	if reference == "" {
+		return nil, errorutils.CheckErrorf("the image reference is empty")
+	}
+	parsed := &Reference{}
+	name := reference
+	if digestIndex := strings.Index(name, "@"); digestIndex != -1 {
+		name, parsed.Digest = name[:digestIndex], name[digestIndex+1:]
+		if err := validateDigest(reference, parsed.Digest); err != nil {
+			return nil, err
+		}
+	}
+	// A colon after the last slash separates the tag. Colons before it belong to the registry port.
+	if tagIndex := strings.LastIndex(name, ":"); tagIndex > strings.LastIndex(name, "/") {
+		name, parsed.Tag = name[:tagIndex], name[tagIndex+1:]
+		if !tagRegexp.MatchString(parsed.Tag) {
+			return nil, errorutils.CheckErrorf("invalid tag '%s' in the image reference '%s'", parsed.Tag, reference)
+		}
+	}
+	components := strings.Split(name, "/")
+	if len(components) > 1 {
+		parsed.Registry, components = components[0], components[1:]
+		if !registryRegexp.MatchString(parsed.Registry) {
+			return nil, errorutils.CheckErrorf("invalid registry '%s' in the image reference '%s'", parsed.Registry, reference)
+		}
+	}
+	for _, component := range components {
+		if !pathComponentRegexp.MatchString(component) {
+			return nil, errorutils.CheckErrorf("invalid path component '%s' in the image reference '%s'. Path components must contain only lowercase letters, digits and separators", component, reference)
+		}
+	}
+	if len(name) > maxReferenceNameLength {
+		return nil, errorutils.CheckErrorf("the name of the image reference '%s' exceeds %d characters", reference, maxReferenceNameLength)
+	}
+	parsed.Namespace = strings.Join(components[:len(components)-1], "/")
+	parsed.Repository = components[len(components)-1]
+	return parsed, nil
+}
+
+func validateDigest(reference, digest string) error {
+	if !digestRegexp.MatchString(digest) {
+		return errorutils.CheckErrorf("invalid digest '%s' in the image reference '%s'", digest, reference)
+	}
+	algorithm, encoded, _ := strings.Cut(digest, ":")
+	if length, ok := digestAlgorithmsHexLength[algorithm]; ok && (len(encoded) != length || strings.ToLower(encoded) != encoded || strings.Trim(encoded, "0123456789abcdef") != "") {
+		return errorutils.CheckErrorf("invalid %s digest '%s' in the image reference '%s'. Expecting %d lowercase hex characters", algorithm, digest, reference, length)
+	}
+	return nil
+}
+
+// Path returns the full repository path in the registry e.g. docker-local/org/hello-world.
+func (reference *Reference) Path() string {
+	return path.Join(reference.Namespace, reference.Repository)
+}
+
+// ManifestReference returns the digest of the image manifest if exists, and the tag otherwise.
+// This is the reference used to request the manifest from the registry.
+func (reference *Reference) ManifestReference() string {
+	if reference.Digest != "" {
+		return reference.Digest
+	}
+	return reference.Tag
+}
+
+// Returns the name of the folder holding the image manifest in Artifactory, which is the tag or the digest for images pushed by digest.
+func (reference *Reference) manifestFolder() string {
+	if reference.Tag != "" {
+		return reference.Tag
+	}
+	return digestToLayer(reference.Digest)
+}
+
+// Returns the given name with the tag and digest of the reference e.g. hello-world:1.0@sha256:<hex>.
+func (reference *Reference) withTagAndDigest(name string) string {
+	if reference.Tag != "" {
+		name += ":" + reference.Tag
+	}
+	if reference.Digest != "" {
+		name += "@" + reference.Digest
+	}
+	return name
+}
+
+func (reference *Reference) String() string {
+	name := reference.Path()
+	if reference.Registry != "" {
+		name = reference.Registry + "/" + name
+	}
+	return reference.withTagAndDigest(name)
+}
@@ -0,0 +1,262 @@
+package container
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"path"
+	"runtime"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Build-info builder, which reads the image directly from the Docker registry API of Artifactory.
+// Unlike the local and remote agents builders, it requires neither a container daemon nor AQL searches,
+// and is therefore suitable for images built by daemonless tools such as BuildKit or Kaniko.
+type RegistryBuildInfoBuilder struct {
+	buildInfoBuilder *buildInfoBuilder
+	registryClient   *RegistryClient
+	commandType      CommandType
+	// The platform of the image to collect, when pulling a multi-platform image.
+	platform Platform
+	// If true, the artifacts attached to the pushed image, such as signatures and SBOMs, are collected as well.
+	collectReferrers bool
+}
+
+func NewRegistryBuildInfoBuilder(image *Image, repository, buildName, buildNumber, project string, serviceManager artifactory.ArtifactoryServicesManager, commandType CommandType) (*RegistryBuildInfoBuilder, error) {
+	builder, err := newBuildInfoBuilder(image, repository, buildName, buildNumber, project, serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	return &RegistryBuildInfoBuilder{
+		buildInfoBuilder: builder,
+		registryClient:   NewArtifactoryRegistryClient(serviceManager, repository),
+		commandType:      commandType,
+		platform:         Platform{Os: "linux", Architecture: runtime.GOARCH},
+		collectReferrers: true,
+	}, nil
+}
+
+// Set the client of the registry, which stores the image. By default, the Docker registry API of the Artifactory repository is used.
+func (rbib *RegistryBuildInfoBuilder) SetRegistryClient(registryClient *RegistryClient) *RegistryBuildInfoBuilder {
+	rbib.registryClient = registryClient
+	return rbib
+}
+
+// Set the platform of the image to collect, when pulling a multi-platform image. The default is linux and the current architecture.
+func (rbib *RegistryBuildInfoBuilder) SetPlatform(os, architecture string) *RegistryBuildInfoBuilder {
+	rbib.platform = Platform{Os: os, Architecture: architecture}
+	return rbib
+}
+
+// Set whether to collect the artifacts attached to the pushed image, such as signatures, attestations and SBOMs. The default is true.
+func (rbib *RegistryBuildInfoBuilder) SetCollectReferrers(collectReferrers bool) *RegistryBuildInfoBuilder {
+	rbib.collectReferrers = collectReferrers
+	return rbib
+}
+
+func (rbib *RegistryBuildInfoBuilder) SetSkipTaggingLayers(skipTaggingLayers bool) {
+	rbib.buildInfoBuilder.skipTaggingLayers = skipTaggingLayers
+}
+
+// Set the base image of the pushed image, and the repository in which it's stored. If the repository is empty, the image repository is used.
+// If not set, the base image is taken from the image manifest annotations, if exist.
+func (rbib *RegistryBuildInfoBuilder) SetBaseImage(baseImage *Image, repo string) *RegistryBuildInfoBuilder {
+	rbib.buildInfoBuilder.setBaseImage(baseImage, repo)
+	return rbib
+}
+
+func (rbib *RegistryBuildInfoBuilder) GetLayers() *[]utils.ResultItem {
+	return &rbib.buildInfoBuilder.imageLayers
+}
+
+// Create build-info for a docker image.
+func (rbib *RegistryBuildInfoBuilder) Build(module string) (*buildinfo.BuildInfo, error) {
+	reference, err := rbib.buildInfoBuilder.image.Reference()
+	if err != nil {
+		return nil, err
+	}
+	imagePath := rbib.getImagePathInRepo(reference)
+	registryManifest, err := rbib.registryClient.GetManifest(imagePath, reference.ManifestReference())
+	if err != nil {
+		return nil, err
+	}
+	manifestFolder := reference.manifestFolder()
+	if registryManifest.IsIndex() {
+		var index FatManifest
+		if err = json.Unmarshal(registryManifest.Content, &index); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if rbib.commandType == Push {
+			return rbib.buildMultiPlatform(imagePath, path.Join(imagePath, manifestFolder), registryManifest, &index, module)
+		}
+		// When pulling a multi-platform image, only the image of the requested platform is pulled.
+		digest := searchManifestDigest(rbib.platform.Os, rbib.platform.Architecture, index.Manifests)
+		if digest == "" {
+			return nil, errorutils.CheckErrorf("the image '%s' has no manifest for the platform %s/%s", rbib.buildInfoBuilder.image.Name(), rbib.platform.Os, rbib.platform.Architecture)
+		}
+		if registryManifest, err = rbib.registryClient.GetManifest(imagePath, digest); err != nil {
+			return nil, err
+		}
+		manifestFolder = digestToLayer(digest)
+	}
+	candidateLayers, imageManifest, err := rbib.collectImage(imagePath, path.Join(imagePath, manifestFolder), registryManifest)
+	if err != nil {
+		return nil, err
+	}
+	rbib.buildInfoBuilder.setImageSha2(imageManifest.Config.Digest)
+	if rbib.commandType == Push {
+		// The config history is required to decide which of the pushed layers are also dependencies.
+		imageConfig := new(configLayer)
+		if err = rbib.registryClient.GetJsonBlob(imagePath, imageManifest.Config.Digest, imageConfig); err != nil {
+			return nil, err
+		}
+		rbib.buildInfoBuilder.imageConfig = imageConfig
+		if err = rbib.collectAttachedArtifacts(imagePath, registryManifest.Digest); err != nil {
+			return nil, err
+		}
+	}
+	candidateLayersMap := make(map[string]*utils.ResultItem, len(candidateLayers))
+	for _, candidateLayer := range candidateLayers {
+		candidateLayersMap[candidateLayer.Name] = candidateLayer
+	}
+	return rbib.buildInfoBuilder.createBuildInfo(rbib.commandType, imageManifest, candidateLayersMap, module)
+}
+
+// Create the build-info of a multi-platform image, with a module for each of the platform images.
+func (rbib *RegistryBuildInfoBuilder) buildMultiPlatform(imagePath, indexPath string, indexManifest *RegistryManifest, index *FatManifest, module string) (*buildinfo.BuildInfo, error) {
+	candidateImages := make(map[string][]*utils.ResultItem)
+	for _, manifestDetails := range index.Manifests {
+		platformManifest, err := rbib.registryClient.GetManifest(imagePath, manifestDetails.Digest)
+		if err != nil {
+			return nil, err
+		}
+		// Artifactory stores the platform images in folders named after their manifest digest, next to the index folder.
+		candidateLayers, _, err := rbib.collectImage(imagePath, path.Join(imagePath, digestToLayer(manifestDetails.Digest)), platformManifest)
+		if err != nil {
+			return nil, err
+		}
+		candidateImages[manifestDetails.Digest] = candidateLayers
+		if err = rbib.collectAttachedArtifacts(imagePath, manifestDetails.Digest); err != nil {
+			return nil, err
+		}
+	}
+	if err := rbib.collectAttachedArtifacts(imagePath, indexManifest.Digest); err != nil {
+		return nil, err
+	}
+	indexItem := rbib.newManifestResultItem(indexPath, "list.manifest.json", indexManifest)
+	return rbib.buildInfoBuilder.createMultiPlatformBuildInfo(index, indexItem, candidateImages, module)
+}
+
+// Collect the manifest, config and layers of a single-platform image from the registry.
+// Returns the image's files, as they are stored in Artifactory, starting with the manifest.
+// Layers missing in the registry, such as foreign layers, are not returned.
+func (rbib *RegistryBuildInfoBuilder) collectImage(imagePath, storagePath string, registryManifest *RegistryManifest) ([]*utils.ResultItem, *manifest, error) {
+	imageManifest := new(manifest)
+	if err := json.Unmarshal(registryManifest.Content, imageManifest); err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	if imageManifest.Config.Digest == "" {
+		return nil, nil, errorutils.CheckErrorf("the manifest '%s' of the image '%s' has no config", registryManifest.Digest, imagePath)
+	}
+	items := []*utils.ResultItem{rbib.newManifestResultItem(storagePath, ManifestJsonFile, registryManifest)}
+	blobs := append([]layer{{Digest: imageManifest.Config.Digest, MediaType: imageManifest.Config.MediaType}}, imageManifest.Layers...)
+	// Manifests may reference the same empty layer more than once.
+	collected := make(map[string]bool)
+	for _, blob := range blobs {
+		if collected[blob.Digest] {
+			continue
+		}
+		collected[blob.Digest] = true
+		descriptor, err := rbib.registryClient.HeadBlob(imagePath, blob.Digest)
+		if err != nil {
+			return nil, nil, err
+		}
+		if descriptor == nil {
+			log.Debug("The blob " + blob.Digest + " of the image '" + imagePath + "' was not found in the registry")
+			continue
+		}
+		items = append(items, &utils.ResultItem{
+			Repo:        rbib.buildInfoBuilder.getSearchableRepo(),
+			Path:        storagePath,
+			Name:        digestToLayer(blob.Digest),
+			Type:        "file",
+			Size:        descriptor.Size,
+			Sha256:      strings.TrimPrefix(blob.Digest, "sha256:"),
+			Actual_Sha1: descriptor.Sha1,
+			Actual_Md5:  descriptor.Md5,
+		})
+	}
+	return items, imageManifest, nil
+}
+
+// Collect the artifacts attached to a manifest, such as signatures, attestations, SBOMs and Helm charts.
+// Artifactory stores the artifacts in folders named after their tag, or after their manifest digest if they aren't tagged.
+func (rbib *RegistryBuildInfoBuilder) collectAttachedArtifacts(imagePath, subject string) error {
+	if !rbib.collectReferrers {
+		return nil
+	}
+	referrers, err := rbib.registryClient.GetReferrers(imagePath, subject)
+	if err != nil {
+		return err
+	}
+	for _, referrer := range referrers {
+		registryManifest, err := rbib.registryClient.GetManifest(imagePath, referrer.Digest)
+		if err != nil {
+			return err
+		}
+		if registryManifest.IsIndex() {
+			log.Debug("Skipping the attached index " + referrer.Digest + " of the manifest " + subject)
+			continue
+		}
+		storageFolder := referrer.Tag
+		if storageFolder == "" {
+			storageFolder = digestToLayer(referrer.Digest)
+		}
+		items, artifactManifest, err := rbib.collectImage(imagePath, path.Join(imagePath, storageFolder), registryManifest)
+		if err != nil {
+			return err
+		}
+		// Artifacts without an artifact type are identified by their config media type.
+		if referrer.ArtifactType == "" {
+			referrer.ArtifactType = artifactManifest.ArtifactType
+		}
+		if referrer.ArtifactType == "" {
+			referrer.ArtifactType = artifactManifest.Config.MediaType
+		}
+		log.Debug("Found the " + referrer.ArtifactType + " artifact " + referrer.Digest + " attached to the manifest " + subject)
+		if rbib.buildInfoBuilder.referrers == nil {
+			rbib.buildInfoBuilder.referrers = make(map[string][]*attachedArtifact)
+		}
+		rbib.buildInfoBuilder.referrers[subject] = append(rbib.buildInfoBuilder.referrers[subject], &attachedArtifact{referrer: referrer, subject: subject, items: items})
+	}
+	return nil
+}
+
+// Create the search result item of a manifest, as it is stored in Artifactory. The checksums are calculated from the manifest content.
+func (rbib *RegistryBuildInfoBuilder) newManifestResultItem(storagePath, name string, registryManifest *RegistryManifest) *utils.ResultItem {
+	sha1Checksum := sha1.Sum(registryManifest.Content)
+	md5Checksum := md5.Sum(registryManifest.Content)
+	return &utils.ResultItem{
+		Repo:        rbib.buildInfoBuilder.getSearchableRepo(),
+		Path:        storagePath,
+		Name:        name,
+		Type:        "json",
+		Size:        int64(len(registryManifest.Content)),
+		Sha256:      strings.TrimPrefix(registryManifest.Digest, "sha256:"),
+		Actual_Sha1: hex.EncodeToString(sha1Checksum[:]),
+		Actual_Md5:  hex.EncodeToString(md5Checksum[:]),
+	}
+}
+
+// Returns the path of the image in the repository.
+// For proxy-less image names, the repository name is removed e.g. docker-local/org/hello-world -> org/hello-world.
+func (rbib *RegistryBuildInfoBuilder) getImagePathInRepo(reference *Reference) string {
+	return strings.TrimPrefix(reference.Path(), rbib.buildInfoBuilder.repositoryDetails.key+"/")
+}
@@ -0,0 +1,487 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	artutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testRegistryRepo = "docker-local"
+	testBuildName    = "registry-build-info-test"
+	testBuildNumber  = "1"
+
+	testRegistryUser     = "admin"
+	testRegistryPassword = "password"
+)
+
+// An in-memory registry, serving the Docker registry API of a single Artifactory repository.
+type testRegistry struct {
+	// <image path>/<tag or digest> -> manifest
+	manifests map[string]*RegistryManifest
+	// digest -> content
+	blobs map[string][]byte
+	// The paths on which build properties were set
+	propsPaths []string
+	// If true, the referrers API returns 404, as in registries which don't support it
+	referrersApiUnsupported bool
+}
+
+func newTestRegistry(t *testing.T) (*testRegistry, artifactory.ArtifactoryServicesManager) {
+	registry, serverDetails := newTestRegistryServer(t)
+	serviceManager, err := artutils.CreateServiceManager(serverDetails, -1, 0, false)
+	assert.NoError(t, err)
+	return registry, serviceManager
+}
+
+// Start the registry server. Returns the registry and the details of the server, which serves as the Artifactory server.
+func newTestRegistryServer(t *testing.T) (*testRegistry, *config.ServerDetails) {
+	// Build properties are set on the pushed images, which requires the local build details.
+	cleanUpJfrogHome, err := tests.SetJfrogHome()
+	assert.NoError(t, err)
+	t.Cleanup(cleanUpJfrogHome)
+	assert.NoError(t, build.SaveBuildGeneralDetails(testBuildName, testBuildNumber, ""))
+	t.Cleanup(func() {
+		assert.NoError(t, build.RemoveBuildDir(testBuildName, testBuildNumber, ""))
+	})
+
+	registry := &testRegistry{manifests: make(map[string]*RegistryManifest), blobs: make(map[string][]byte)}
+	server := httptest.NewServer(http.HandlerFunc(registry.handle))
+	t.Cleanup(server.Close)
+	return registry, &config.ServerDetails{ArtifactoryUrl: server.URL + "/", User: testRegistryUser, Password: testRegistryPassword}
+}
+
+func (tr *testRegistry) handle(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/api/repositories/"+testRegistryRepo:
+		_, _ = w.Write([]byte(`{"key":"` + testRegistryRepo + `","rclass":"local","packageType":"docker"}`))
+	case strings.HasPrefix(r.URL.Path, "/api/storage/") && r.Method == http.MethodPut:
+		tr.propsPaths = append(tr.propsPaths, strings.TrimPrefix(r.URL.Path, "/api/storage/"))
+		w.WriteHeader(http.StatusNoContent)
+	case strings.HasPrefix(r.URL.Path, "/api/docker/"+testRegistryRepo+"/v2/"):
+		tr.handleRegistryApi(w, r, strings.TrimPrefix(r.URL.Path, "/api/docker/"+testRegistryRepo+"/v2/"))
+	// The registry API served on the registry host, as accessed by the Crane container manager
+	case r.URL.Path == "/v2/":
+		if user, password, _ := r.BasicAuth(); user != testRegistryUser || password != testRegistryPassword {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	case strings.HasPrefix(r.URL.Path, "/v2/"):
+		tr.handleRegistryApi(w, r, strings.TrimPrefix(r.URL.Path, "/v2/"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (tr *testRegistry) handleRegistryApi(w http.ResponseWriter, r *http.Request, apiPath string) {
+	if imagePath, subject, found := strings.Cut(apiPath, "/referrers/"); found {
+		if tr.referrersApiUnsupported {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", ociIndexMediaType)
+		content, _ := json.Marshal(tr.getReferrers(imagePath, subject))
+		_, _ = w.Write(content)
+		return
+	}
+	if imagePath, reference, found := strings.Cut(apiPath, "/manifests/"); found {
+		registryManifest, ok := tr.manifests[imagePath+"/"+reference]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", registryManifest.MediaType)
+		w.Header().Set(dockerContentDigestHeader, registryManifest.Digest)
+		_, _ = w.Write(registryManifest.Content)
+		return
+	}
+	if _, digest, found := strings.Cut(apiPath, "/blobs/"); found {
+		content, ok := tr.blobs[digest]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set(checksumSha1Header, "sha1-"+digest)
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		if r.Method != http.MethodHead {
+			_, _ = w.Write(content)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// Returns the manifests whose subject is the given manifest, sorted by digest.
+func (tr *testRegistry) getReferrers(imagePath, subject string) referrersIndex {
+	index := referrersIndex{MediaType: ociIndexMediaType, Manifests: []descriptor{}}
+	for key, registryManifest := range tr.manifests {
+		if key != imagePath+"/"+registryManifest.Digest {
+			continue
+		}
+		var artifactManifest manifest
+		if json.Unmarshal(registryManifest.Content, &artifactManifest) != nil || artifactManifest.Subject == nil || artifactManifest.Subject.Digest != subject {
+			continue
+		}
+		index.Manifests = append(index.Manifests, descriptor{MediaType: registryManifest.MediaType, Digest: registryManifest.Digest, Size: int64(len(registryManifest.Content)), ArtifactType: artifactManifest.ArtifactType})
+	}
+	sort.Slice(index.Manifests, func(i, j int) bool { return index.Manifests[i].Digest < index.Manifests[j].Digest })
+	return index
+}
+
+// Add an OCI artifact with a single layer. If subject is not empty, the artifact is attached to it. Returns the artifact digest.
+func (tr *testRegistry) addArtifact(t *testing.T, imagePath, artifactType, subject, content string, tags ...string) string {
+	emptyConfig := tr.addBlob(t, []byte("{}"))
+	artifactManifest := manifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		ArtifactType:  artifactType,
+		Config:        manifestConfig{Digest: emptyConfig.Digest, MediaType: "application/vnd.oci.empty.v1+json", Size: emptyConfig.Size},
+		Layers:        []layer{tr.addBlob(t, []byte(content))},
+	}
+	if subject != "" {
+		artifactManifest.Subject = &descriptor{MediaType: ociManifestMediaType, Digest: subject}
+	}
+	return tr.addManifest(t, imagePath, ociManifestMediaType, artifactManifest, tags...)
+}
+
+func (tr *testRegistry) addBlob(t *testing.T, content any) layer {
+	rawContent, ok := content.([]byte)
+	if !ok {
+		var err error
+		rawContent, err = json.Marshal(content)
+		assert.NoError(t, err)
+	}
+	digest := calcDigest(rawContent)
+	tr.blobs[digest] = rawContent
+	return layer{Digest: digest, MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Size: int64(len(rawContent))}
+}
+
+// Add a manifest to the registry under its digest and the given tags.
+func (tr *testRegistry) addManifest(t *testing.T, imagePath, mediaType string, content any, tags ...string) string {
+	rawContent, err := json.Marshal(content)
+	assert.NoError(t, err)
+	registryManifest := &RegistryManifest{MediaType: mediaType, Digest: calcDigest(rawContent), Content: rawContent}
+	for _, reference := range append(tags, registryManifest.Digest) {
+		tr.manifests[imagePath+"/"+reference] = registryManifest
+	}
+	return registryManifest.Digest
+}
+
+// Add a single-platform image with a base layer and an application layer. Returns the manifest digest and the image layers.
+func (tr *testRegistry) addImage(t *testing.T, imagePath, platform string, tags ...string) (string, []layer) {
+	imageConfig := tr.addBlob(t, map[string]any{
+		"os":           "linux",
+		"architecture": platform,
+		"history": []history{
+			{CreatedBy: "ADD file:base in /"},
+			{CreatedBy: "ENTRYPOINT [\"/bin/sh\"]", EmptyLayer: true},
+			{CreatedBy: "COPY app /app"},
+		},
+	})
+	layers := []layer{tr.addBlob(t, []byte("base-"+platform)), tr.addBlob(t, []byte("app-"+platform))}
+	digest := tr.addManifest(t, imagePath, ociManifestMediaType, manifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		Config:        manifestConfig{Digest: imageConfig.Digest, MediaType: "application/vnd.oci.image.config.v1+json", Size: imageConfig.Size},
+		Layers:        layers,
+	}, tags...)
+	return digest, layers
+}
+
+func getModuleProperty(module buildinfo.Module, key string) string {
+	properties, _ := module.Properties.(map[string]string)
+	return properties[key]
+}
+
+func getArtifactNames(artifacts []buildinfo.Artifact) (names []string) {
+	for _, artifact := range artifacts {
+		names = append(names, artifact.Name)
+	}
+	return
+}
+
+func TestRegistryBuildInfoBuilderPush(t *testing.T) {
+	registry, serviceManager := newTestRegistry(t)
+	_, layers := registry.addImage(t, "hello-world", "amd64", "1.0")
+
+	builder, err := NewRegistryBuildInfoBuilder(NewImage("my-registry.io/"+testRegistryRepo+"/hello-world:1.0"), testRegistryRepo, testBuildName, testBuildNumber, "", serviceManager, Push)
+	assert.NoError(t, err)
+	buildInfo, err := builder.Build("")
+	if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 1) {
+		return
+	}
+	module := buildInfo.Modules[0]
+	assert.Equal(t, "hello-world:1.0", module.Id)
+
+	// The manifest, the config and the two layers are artifacts
+	assert.Len(t, module.Artifacts, 4)
+	assert.Equal(t, ManifestJsonFile, module.Artifacts[0].Name)
+	assert.Equal(t, "hello-world/1.0/manifest.json", module.Artifacts[0].Path)
+	assert.NotEmpty(t, module.Artifacts[0].Sha1)
+	assert.Contains(t, getArtifactNames(module.Artifacts), digestToLayer(layers[1].Digest))
+	assert.Equal(t, "sha1-"+layers[0].Digest, module.Artifacts[2].Sha1)
+
+	// Only the base layer, created before the ENTRYPOINT instruction, is a dependency
+	if assert.Len(t, module.Dependencies, 1) {
+		assert.Equal(t, digestToLayer(layers[0].Digest), module.Dependencies[0].Id)
+	}
+
+	// Build properties are set on the image files
+	assert.Len(t, *builder.GetLayers(), 4)
+	assert.Len(t, registry.propsPaths, 4)
+}
+
+func TestRegistryBuildInfoBuilderPushByDigest(t *testing.T) {
+	registry, serviceManager := newTestRegistry(t)
+	digest, _ := registry.addImage(t, "org/hello-world", "amd64")
+	// Add a missing layer, such as a foreign layer
+	registryManifest := registry.manifests["org/hello-world/"+digest]
+	var imageManifest manifest
+	assert.NoError(t, json.Unmarshal(registryManifest.Content, &imageManifest))
+	imageManifest.Layers = append(imageManifest.Layers, layer{Digest: calcDigest([]byte("foreign")), MediaType: foreignLayerMediaType})
+	digest = registry.addManifest(t, "org/hello-world", ociManifestMediaType, imageManifest)
+
+	builder, err := NewRegistryBuildInfoBuilder(NewImage("my-registry.io/org/hello-world@"+digest), testRegistryRepo, testBuildName, testBuildNumber, "", serviceManager, Push)
+	assert.NoError(t, err)
+	builder.SetSkipTaggingLayers(true)
+	buildInfo, err := builder.Build("my-module")
+	if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 1) {
+		return
+	}
+	assert.Equal(t, "my-module", buildInfo.Modules[0].Id)
+	assert.Len(t, buildInfo.Modules[0].Artifacts, 4)
+	assert.Equal(t, "org/hello-world/"+digestToLayer(digest)+"/manifest.json", buildInfo.Modules[0].Artifacts[0].Path)
+	assert.Empty(t, registry.propsPaths)
+}
+
+func TestRegistryBuildInfoBuilderMultiPlatform(t *testing.T) {
+	registry, serviceManager := newTestRegistry(t)
+	amdDigest, _ := registry.addImage(t, "hello-world", "amd64")
+	armDigest, armLayers := registry.addImage(t, "hello-world", "arm64")
+	registry.addManifest(t, "hello-world", ociIndexMediaType, FatManifest{
+		MediaType: ociIndexMediaType,
+		Manifests: []ManifestDetails{
+			{Digest: amdDigest, MediaType: ociManifestMediaType, Platform: Platform{Os: "linux", Architecture: "amd64"}},
+			{Digest: armDigest, MediaType: ociManifestMediaType, Platform: Platform{Os: "linux", Architecture: "arm64"}},
+		},
+	}, "latest")
+
+	t.Run("push", func(t *testing.T) {
+		builder, err := NewRegistryBuildInfoBuilder(NewImage("my-registry.io/hello-world"), testRegistryRepo, testBuildName, testBuildNumber, "", serviceManager, Push)
+		assert.NoError(t, err)
+		buildInfo, err := builder.Build("")
+		if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 3) {
+			return
+		}
+		assert.Equal(t, "hello-world:latest", buildInfo.Modules[0].Id)
+		assert.Equal(t, []string{"list.manifest.json"}, getArtifactNames(buildInfo.Modules[0].Artifacts))
+		assert.Equal(t, "linux/amd64/hello-world:latest", buildInfo.Modules[1].Id)
+		assert.Equal(t, "linux/arm64/hello-world:latest", buildInfo.Modules[2].Id)
+		assert.Equal(t, "hello-world:latest", buildInfo.Modules[2].Parent)
+		assert.Len(t, buildInfo.Modules[2].Artifacts, 4)
+		assert.Equal(t, "hello-world/"+digestToLayer(armDigest)+"/manifest.json", buildInfo.Modules[2].Artifacts[0].Path)
+	})
+
+	t.Run("pull", func(t *testing.T) {
+		builder, err := NewRegistryBuildInfoBuilder(NewImage("my-registry.io/hello-world:latest"), testRegistryRepo, testBuildName, testBuildNumber, "", serviceManager, Pull)
+		assert.NoError(t, err)
+		buildInfo, err := builder.SetPlatform("linux", "arm64").Build("")
+		if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 1) {
+			return
+		}
+		dependencies := buildInfo.Modules[0].Dependencies
+		// The manifest, the config and the two layers are dependencies
+		if !assert.Len(t, dependencies, 4) {
+			return
+		}
+		assert.Equal(t, ManifestJsonFile, dependencies[0].Id)
+		assert.Equal(t, digestToLayer(armLayers[1].Digest), dependencies[3].Id)
+	})
+
+	t.Run("pull missing platform", func(t *testing.T) {
+		builder, err := NewRegistryBuildInfoBuilder(NewImage("my-registry.io/hello-world:latest"), testRegistryRepo, testBuildName, testBuildNumber, "", serviceManager, Pull)
+		assert.NoError(t, err)
+		_, err = builder.SetPlatform("windows", "amd64").Build("")
+		assert.ErrorContains(t, err, "has no manifest for the platform windows/amd64")
+	})
+}
+
+func TestRegistryBuildInfoBuilderAttachedArtifacts(t *testing.T) {
+	registry, serviceManager := newTestRegistry(t)
+	digest, _ := registry.addImage(t, "hello-world", "amd64", "1.0")
+	sbomDigest := registry.addArtifact(t, "hello-world", "application/spdx+json", digest, "sbom")
+	helmDigest := registry.addArtifact(t, "hello-world", "application/vnd.cncf.helm.config.v1+json", digest, "chart")
+	// A signature attached by cosign using a tag, without a subject
+	signatureTag := strings.Replace(digest, ":", "-", 1) + ".sig"
+	signatureDigest := registry.addArtifact(t, "hello-world", "", "", "signature", signatureTag)
+
+	buildAndAssert := func(t *testing.T) {
+		builder, err := NewRegistryBuildInfoBuilder(NewImage("my-registry.io/hello-world:1.0"), testRegistryRepo, testBuildName, testBuildNumber, "", serviceManager, Push)
+		assert.NoError(t, err)
+		buildInfo, err := builder.Build("")
+		if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 4) {
+			return
+		}
+		modulesById := make(map[string]buildinfo.Module)
+		for _, module := range buildInfo.Modules[1:] {
+			assert.Equal(t, "hello-world:1.0", module.Parent)
+			assert.Equal(t, digest, getModuleProperty(module, "oci.subject.digest"))
+			assert.Len(t, module.Artifacts, 3)
+			modulesById[module.Id] = module
+		}
+		sbomModule := modulesById["referrers/hello-world:1.0/"+digestToLayer(sbomDigest)]
+		assert.Equal(t, "application/spdx+json", getModuleProperty(sbomModule, "oci.artifact.type"))
+		if assert.NotEmpty(t, sbomModule.Artifacts) {
+			assert.Equal(t, "hello-world/"+digestToLayer(sbomDigest)+"/manifest.json", sbomModule.Artifacts[0].Path)
+		}
+		assert.Equal(t, "application/vnd.cncf.helm.config.v1+json", getModuleProperty(modulesById["referrers/hello-world:1.0/"+digestToLayer(helmDigest)], "oci.artifact.type"))
+		signatureModule := modulesById["referrers/hello-world:1.0/"+digestToLayer(signatureDigest)]
+		assert.Equal(t, cosignSignatureArtifactType, getModuleProperty(signatureModule, "oci.artifact.type"))
+		if assert.NotEmpty(t, signatureModule.Artifacts) {
+			assert.Equal(t, "hello-world/"+signatureTag+"/manifest.json", signatureModule.Artifacts[0].Path)
+		}
+		// Build properties are set on the image files and on the attached artifacts files
+		assert.Len(t, *builder.GetLayers(), 4+3*3)
+	}
+
+	t.Run("referrers api", buildAndAssert)
+
+	t.Run("referrers tag schema", func(t *testing.T) {
+		registry.referrersApiUnsupported = true
+		defer func() { registry.referrersApiUnsupported = false }()
+		index := registry.getReferrers("hello-world", digest)
+		registry.addManifest(t, "hello-world", ociIndexMediaType, index, strings.Replace(digest, ":", "-", 1))
+		buildAndAssert(t)
+	})
+
+	t.Run("skip attached artifacts", func(t *testing.T) {
+		builder, err := NewRegistryBuildInfoBuilder(NewImage("my-registry.io/hello-world:1.0"), testRegistryRepo, testBuildName, testBuildNumber, "", serviceManager, Push)
+		assert.NoError(t, err)
+		buildInfo, err := builder.SetCollectReferrers(false).Build("")
+		if assert.NoError(t, err) {
+			assert.Len(t, buildInfo.Modules, 1)
+		}
+	})
+}
+
+func TestRegistryClientGetManifestDigestMismatch(t *testing.T) {
+	registry, serviceManager := newTestRegistry(t)
+	digest, _ := registry.addImage(t, "hello-world", "amd64", "1.0")
+	registryClient := NewArtifactoryRegistryClient(serviceManager, testRegistryRepo)
+
+	registryManifest, err := registryClient.GetManifest("hello-world", "1.0")
+	if assert.NoError(t, err) {
+		assert.Equal(t, digest, registryManifest.Digest)
+		assert.False(t, registryManifest.IsIndex())
+	}
+
+	// Corrupt the manifest content
+	registry.manifests["hello-world/1.0"] = &RegistryManifest{MediaType: ociManifestMediaType, Digest: digest, Content: []byte(`{}`)}
+	_, err = registryClient.GetManifest("hello-world", "1.0")
+	assert.ErrorContains(t, err, fmt.Sprintf("while the registry reported '%s'", digest))
+
+	descriptor, err := registryClient.HeadBlob("hello-world", calcDigest([]byte("missing")))
+	assert.NoError(t, err)
+	assert.Nil(t, descriptor)
+}
+
+func TestRegistryBuildInfoBuilderBaseImage(t *testing.T) {
+	registry, serviceManager := newTestRegistry(t)
+	_, layers := registry.addImage(t, "hello-world", "amd64", "1.0")
+	baseConfig := registry.addBlob(t, map[string]any{"os": "linux", "architecture": "amd64"})
+	baseDigest := registry.addManifest(t, "library/alpine", ociManifestMediaType, manifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		Config:        manifestConfig{Digest: baseConfig.Digest, MediaType: "application/vnd.oci.image.config.v1+json", Size: baseConfig.Size},
+		Layers:        layers[:1],
+	}, "3.19")
+	baseIndexDigest := registry.addManifest(t, "library/alpine", ociIndexMediaType, FatManifest{
+		MediaType: ociIndexMediaType,
+		Manifests: []ManifestDetails{{Digest: baseDigest, MediaType: ociManifestMediaType, Platform: Platform{Os: "linux", Architecture: "amd64"}}},
+	}, "multi-platform")
+	otherBaseDigest := registry.addManifest(t, "busybox", ociManifestMediaType, manifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		Config:        manifestConfig{Digest: baseConfig.Digest},
+		Layers:        []layer{registry.addBlob(t, []byte("busybox"))},
+	}, "latest")
+
+	build := func(t *testing.T, imageName string, baseImage *Image) (buildinfo.Module, bool) {
+		builder, err := NewRegistryBuildInfoBuilder(NewImage(imageName), testRegistryRepo, testBuildName, testBuildNumber, "", serviceManager, Push)
+		assert.NoError(t, err)
+		builder.SetSkipTaggingLayers(true)
+		if baseImage != nil {
+			builder.SetBaseImage(baseImage, testRegistryRepo)
+		}
+		buildInfo, err := builder.Build("")
+		if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 1) {
+			return buildinfo.Module{}, false
+		}
+		return buildInfo.Modules[0], true
+	}
+
+	t.Run("explicit base image", func(t *testing.T) {
+		module, ok := build(t, "my-registry.io/hello-world:1.0", NewImage("my-registry.io/"+testRegistryRepo+"/library/alpine:3.19"))
+		if !ok {
+			return
+		}
+		assert.Equal(t, "my-registry.io/"+testRegistryRepo+"/library/alpine:3.19", getModuleProperty(module, BaseImageNameProperty))
+		assert.Equal(t, baseDigest, getModuleProperty(module, BaseImageDigestProperty))
+		if assert.Len(t, module.Dependencies, 1) {
+			assert.Equal(t, digestToLayer(layers[0].Digest), module.Dependencies[0].Id)
+			assert.Equal(t, [][]string{{"my-registry.io/" + testRegistryRepo + "/library/alpine:3.19@" + baseDigest}}, module.Dependencies[0].RequestedBy)
+			assert.Equal(t, []string{BaseImageScope}, module.Dependencies[0].Scopes)
+		}
+		assert.Equal(t, "true", getModuleProperty(module, "docker.image.layer.1.baseImage"))
+		assert.Equal(t, "COPY app /app", getModuleProperty(module, "docker.image.layer.2.createdBy"))
+		layersDetails, err := GetLayersDetails(module)
+		assert.NoError(t, err)
+		assert.Equal(t, []LayerDetails{
+			{Digest: layers[0].Digest, Size: layers[0].Size, CreatedBy: "ADD file:base in /", BaseImage: true},
+			{Digest: layers[1].Digest, Size: layers[1].Size, CreatedBy: "COPY app /app"},
+		}, layersDetails)
+	})
+
+	t.Run("base image annotations", func(t *testing.T) {
+		var imageManifest manifest
+		assert.NoError(t, json.Unmarshal(registry.manifests["hello-world/1.0"].Content, &imageManifest))
+		imageManifest.Annotations = map[string]string{baseImageNameAnnotation: "docker.io/library/alpine:multi-platform", baseImageDigestAnnotation: baseIndexDigest}
+		registry.addManifest(t, "hello-world", ociManifestMediaType, imageManifest, "annotated")
+		module, ok := build(t, "my-registry.io/hello-world:annotated", nil)
+		if !ok {
+			return
+		}
+		assert.Equal(t, "docker.io/library/alpine:multi-platform", getModuleProperty(module, BaseImageNameProperty))
+		assert.Equal(t, baseDigest, getModuleProperty(module, BaseImageDigestProperty))
+		assert.Len(t, module.Dependencies, 1)
+	})
+
+	t.Run("mismatching base image", func(t *testing.T) {
+		module, ok := build(t, "my-registry.io/hello-world:1.0", NewImage("busybox@"+otherBaseDigest))
+		if !ok {
+			return
+		}
+		assert.Empty(t, getModuleProperty(module, BaseImageNameProperty))
+		// The dependencies are decided by the config history
+		if assert.Len(t, module.Dependencies, 1) {
+			assert.Nil(t, module.Dependencies[0].RequestedBy)
+			assert.Empty(t, module.Dependencies[0].Scopes)
+		}
+		layersDetails, err := GetLayersDetails(module)
+		if assert.NoError(t, err) && assert.Len(t, layersDetails, 2) {
+			assert.False(t, layersDetails[0].BaseImage)
+		}
+	})
+}
@@ -0,0 +1,244 @@
+package container
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	dockerManifestMediaType     = "application/vnd.docker.distribution.manifest.v2+json"
+	dockerManifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+	ociManifestMediaType        = "application/vnd.oci.image.manifest.v1+json"
+	ociIndexMediaType           = "application/vnd.oci.image.index.v1+json"
+
+	dockerContentDigestHeader = "Docker-Content-Digest"
+	// Checksum headers returned by Artifactory, which are not part of the distribution spec.
+	checksumSha1Header = "X-Checksum-Sha1"
+	checksumMd5Header  = "X-Checksum-Md5"
+
+	// Artifact types of the artifacts attached by cosign using tags, rather than the referrers API.
+	cosignSignatureArtifactType   = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	cosignAttestationArtifactType = "application/vnd.dsse.envelope.v1+json"
+	cosignSbomArtifactType        = "application/vnd.dev.cosign.artifact.sbom.v1+json"
+)
+
+var manifestMediaTypes = []string{ociIndexMediaType, ociManifestMediaType, dockerManifestListMediaType, dockerManifestMediaType}
+
+// RegistryClient is a client of the OCI distribution API, which doesn't require a container daemon.
+type RegistryClient struct {
+	// The URL of the registry, without the '/v2' API prefix e.g. https://acme.jfrog.io/artifactory/api/docker/docker-local
+	url         string
+	client      *jfroghttpclient.JfrogHttpClient
+	httpDetails httputils.HttpClientDetails
+}
+
+func NewRegistryClient(url string, client *jfroghttpclient.JfrogHttpClient, httpDetails httputils.HttpClientDetails) *RegistryClient {
+	return &RegistryClient{url: strings.TrimSuffix(url, "/"), client: client, httpDetails: httpDetails}
+}
+
+// Create a client of the Docker registry API of an Artifactory repository.
+// The image names passed to the client are relative to the repository e.g. org/hello-world.
+func NewArtifactoryRegistryClient(serviceManager artifactory.ArtifactoryServicesManager, repo string) *RegistryClient {
+	serviceDetails := serviceManager.GetConfig().GetServiceDetails()
+	return NewRegistryClient(serviceDetails.GetUrl()+"api/docker/"+repo, serviceManager.Client(), serviceDetails.CreateHttpClientDetails())
+}
+
+// A manifest or an index, as returned by the registry.
+type RegistryManifest struct {
+	MediaType string
+	// The digest of the manifest content e.g. sha256:<hex>
+	Digest  string
+	Content []byte
+}
+
+func (rm *RegistryManifest) IsIndex() bool {
+	return rm.MediaType == ociIndexMediaType || rm.MediaType == dockerManifestListMediaType
+}
+
+// A blob, as described by the registry.
+type BlobDescriptor struct {
+	Digest string
+	Size   int64
+	// The SHA1 and MD5 checksums are returned only by registries which provide them, such as Artifactory.
+	Sha1 string
+	Md5  string
+}
+
+// The tag suffixes used by cosign to attach artifacts to a manifest e.g. sha256-<hex>.sig
+var cosignTagSuffixes = []struct {
+	suffix       string
+	artifactType string
+}{{".sig", cosignSignatureArtifactType}, {".att", cosignAttestationArtifactType}, {".sbom", cosignSbomArtifactType}}
+
+// An artifact attached to a manifest, such as a signature, an attestation, an SBOM or a Helm chart.
+type Referrer struct {
+	Digest       string
+	MediaType    string
+	ArtifactType string
+	Size         int64
+	// The tag by which the artifact is attached, if it is attached using a tag rather than the subject field e.g. sha256-<hex>.sig
+	Tag string
+}
+
+// Get a manifest or an index by tag or digest. The content of the manifest is verified against its digest.
+func (rc *RegistryClient) GetManifest(name, reference string) (*RegistryManifest, error) {
+	registryManifest, err := rc.getManifestIfExists(name, reference)
+	if err != nil || registryManifest != nil {
+		return registryManifest, err
+	}
+	return nil, errorutils.CheckErrorf("the manifest '%s' of the image '%s' was not found", reference, name)
+}
+
+// Get a manifest or an index by tag or digest. Returns nil if the manifest doesn't exist.
+func (rc *RegistryClient) getManifestIfExists(name, reference string) (*RegistryManifest, error) {
+	httpDetails := rc.httpDetails.Clone()
+	httpDetails.AddHeader("Accept", strings.Join(manifestMediaTypes, ", "))
+	resp, body, _, err := rc.client.SendGet(rc.apiUrl(name, "manifests", reference), true, httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, errorutils.CheckErrorf("failed to get the manifest '%s' of the image '%s': %s", reference, name, err.Error())
+	}
+	registryManifest := &RegistryManifest{MediaType: strings.Split(resp.Header.Get("Content-Type"), ";")[0], Content: body, Digest: calcDigest(body)}
+	if expectedDigest := resp.Header.Get(dockerContentDigestHeader); expectedDigest != "" && expectedDigest != registryManifest.Digest {
+		return nil, errorutils.CheckErrorf("the digest of the manifest '%s' of the image '%s' is '%s', while the registry reported '%s'", reference, name, registryManifest.Digest, expectedDigest)
+	}
+	if strings.HasPrefix(reference, "sha256:") && reference != registryManifest.Digest {
+		return nil, errorutils.CheckErrorf("the registry returned the manifest '%s' of the image '%s', while the manifest '%s' was requested", registryManifest.Digest, name, reference)
+	}
+	if registryManifest.MediaType == "" || registryManifest.MediaType == "application/json" {
+		// Some registries don't return the content type. Take it from the manifest itself.
+		var content struct {
+			MediaType string `json:"mediaType"`
+		}
+		if err = json.Unmarshal(body, &content); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		registryManifest.MediaType = content.MediaType
+	}
+	log.Debug(fmt.Sprintf("Got the manifest '%s' of the image '%s' with media type '%s'", registryManifest.Digest, name, registryManifest.MediaType))
+	return registryManifest, nil
+}
+
+// Get the artifacts attached to a manifest.
+// Artifacts are listed using the referrers API. If the registry doesn't support it, the referrers tag schema is used instead e.g. sha256-<hex>.
+// Artifacts attached by cosign using tags e.g. sha256-<hex>.sig, are returned as well.
+func (rc *RegistryClient) GetReferrers(name, digest string) ([]*Referrer, error) {
+	index, err := rc.getReferrersIndex(name, digest)
+	if err != nil {
+		return nil, err
+	}
+	var referrers []*Referrer
+	for _, manifestDescriptor := range index.Manifests {
+		referrers = append(referrers, &Referrer{Digest: manifestDescriptor.Digest, MediaType: manifestDescriptor.MediaType, ArtifactType: manifestDescriptor.ArtifactType, Size: manifestDescriptor.Size})
+	}
+	tagPrefix := strings.Replace(digest, ":", "-", 1)
+	for _, cosignTag := range cosignTagSuffixes {
+		registryManifest, err := rc.getManifestIfExists(name, tagPrefix+cosignTag.suffix)
+		if err != nil {
+			return nil, err
+		}
+		if registryManifest != nil {
+			referrers = append(referrers, &Referrer{
+				Digest:       registryManifest.Digest,
+				MediaType:    registryManifest.MediaType,
+				ArtifactType: cosignTag.artifactType,
+				Size:         int64(len(registryManifest.Content)),
+				Tag:          tagPrefix + cosignTag.suffix,
+			})
+		}
+	}
+	return referrers, nil
+}
+
+func (rc *RegistryClient) getReferrersIndex(name, digest string) (*referrersIndex, error) {
+	index := new(referrersIndex)
+	httpDetails := rc.httpDetails.Clone()
+	httpDetails.AddHeader("Accept", ociIndexMediaType)
+	resp, body, _, err := rc.client.SendGet(rc.apiUrl(name, "referrers", digest), true, httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return index, errorutils.CheckError(json.Unmarshal(body, index))
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		return nil, errorutils.CheckErrorf("failed to get the referrers of the manifest '%s' of the image '%s': %s", digest, name, errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK).Error())
+	}
+	// The referrers API isn't supported. Fall back to the referrers tag schema.
+	log.Debug("The referrers API isn't supported by the registry. Looking for the referrers tag of the manifest " + digest)
+	registryManifest, err := rc.getManifestIfExists(name, strings.Replace(digest, ":", "-", 1))
+	if err != nil || registryManifest == nil {
+		return index, err
+	}
+	return index, errorutils.CheckError(json.Unmarshal(registryManifest.Content, index))
+}
+
+// Get the details of a blob without downloading it. Returns nil if the blob doesn't exist.
+func (rc *RegistryClient) HeadBlob(name, digest string) (*BlobDescriptor, error) {
+	resp, _, err := rc.client.SendHead(rc.apiUrl(name, "blobs", digest), &rc.httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return nil, errorutils.CheckErrorf("failed to get the blob '%s' of the image '%s': %s", digest, name, err.Error())
+	}
+	descriptor := &BlobDescriptor{Digest: digest, Sha1: resp.Header.Get(checksumSha1Header), Md5: resp.Header.Get(checksumMd5Header)}
+	if contentLength := resp.Header.Get("Content-Length"); contentLength != "" {
+		if descriptor.Size, err = strconv.ParseInt(contentLength, 10, 64); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	return descriptor, nil
+}
+
+// Download a JSON blob, such as the image config, and unmarshal it into the result.
+func (rc *RegistryClient) GetJsonBlob(name, digest string, result interface{}) error {
+	resp, body, _, err := rc.client.SendGet(rc.apiUrl(name, "blobs", digest), true, &rc.httpDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return errorutils.CheckErrorf("failed to get the blob '%s' of the image '%s': %s", digest, name, err.Error())
+	}
+	if actualDigest := calcDigest(body); strings.HasPrefix(digest, "sha256:") && actualDigest != digest {
+		return errorutils.CheckErrorf("the digest of the blob '%s' of the image '%s' is '%s'", digest, name, actualDigest)
+	}
+	return errorutils.CheckError(json.Unmarshal(body, result))
+}
+
+// Ping the registry API, to verify that the registry is reachable and accepts the credentials.
+func (rc *RegistryClient) Ping() error {
+	resp, body, _, err := rc.client.SendGet(rc.url+"/v2/", true, &rc.httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
+
+func (rc *RegistryClient) apiUrl(name, endpoint, reference string) string {
+	return fmt.Sprintf("%s/v2/%s/%s/%s", rc.url, name, endpoint, reference)
+}
+
+func calcDigest(content []byte) string {
+	checksum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(checksum[:])
+}
@@ -24,6 +24,13 @@ func NewRemoteAgentBuildInfoBuilder(image *Image, repository, buildName, buildNu
 	}, err
 }
 
+// Set the base image of the pushed image, and the repository in which it's stored. If the repository is empty, the image repository is used.
+// If not set, the base image is taken from the image manifest annotations, if exist.
+func (rabib *RemoteAgentBuildInfoBuilder) SetBaseImage(baseImage *Image, repo string) *RemoteAgentBuildInfoBuilder {
+	rabib.buildInfoBuilder.setBaseImage(baseImage, repo)
+	return rabib
+}
+
 func (rabib *RemoteAgentBuildInfoBuilder) GetLayers() *[]utils.ResultItem {
 	return &rabib.buildInfoBuilder.imageLayers
 }
@@ -86,14 +93,13 @@ func (rabib *RemoteAgentBuildInfoBuilder) handleFatManifestImage(results map[str
 
 // Search image manifest or fat-manifest of and image.
 func (rabib *RemoteAgentBuildInfoBuilder) searchImage() (resultMap map[string]*utils.ResultItem, err error) {
-	longImageName, err := rabib.buildInfoBuilder.image.GetImageLongNameWithTag()
+	reference, err := rabib.buildInfoBuilder.image.Reference()
 	if err != nil {
 		return nil, err
 	}
-	imagePath := strings.Replace(longImageName, ":", "/", 1)
 
 	// Search image's manifest.
-	manifestPathsCandidates := getManifestPaths(imagePath, rabib.buildInfoBuilder.getSearchableRepo(), Push)
+	manifestPathsCandidates := getManifestPaths(reference, rabib.buildInfoBuilder.getSearchableRepo(), Push)
 	log.Debug("Start searching for image manifest.json")
 	for _, path := range manifestPathsCandidates {
 		log.Debug(`Searching in:"` + path + `"`)
@@ -5,6 +5,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -63,8 +64,21 @@ const (
 	setServerIdError           = "server ID must be set. Use the --server-id-resolve/deploy flag or configure a default server using 'jfrog c add' and 'jfrog c use' commands. "
 	setRepositoryError         = "repository/ies must be set. "
 	setSnapshotAndReleaseError = "snapshot and release repositories must be set. "
+	unsupportedRepositoryError = "this repository is not supported for %s projects. "
 )
 
+// Project types that only resolve dependencies from Artifactory, and have no deployment repository.
+var resolutionOnlyProjectTypes = []string{project.Composer.String()}
+
+// Repositories suggested during the interactive configuration of these project types are filtered by their package type.
+var projectTypeToPackageType = map[string]string{
+	project.Cargo.String():    "cargo",
+	project.Conan.String():    "conan",
+	project.Helm.String():     "helm",
+	project.Composer.String(): "composer",
+	project.Sbt.String():      "sbt",
+}
+
 type ConfigFile struct {
 	Interactive bool               `yaml:"-"`
 	Version     int                `yaml:"version,omitempty"`
@@ -177,6 +191,10 @@ func handleInteractiveConfigCreation(configFile *ConfigFile, confType project.Pr
 		return configFile.setDeployerResolver()
 	case project.Swift:
 		return configFile.setDeployerResolver()
+	case project.Cargo, project.Conan, project.Helm, project.Sbt:
+		return configFile.setDeployerResolver()
+	case project.Composer:
+		return configFile.setResolver(false)
 	}
 	return
 }
@@ -475,7 +493,7 @@ func (configFile *ConfigFile) setDeployer(withSnapshot bool) error {
 
 	// Set deployment repository
 	if configFile.Deployer.ServerId != "" {
-		deployerRepos, err := getRepositories(configFile.Deployer.ServerId, utils.Virtual, utils.Local)
+		deployerRepos, err := getRepositories(configFile.Deployer.ServerId, projectTypeToPackageType[configFile.ConfigType], utils.Virtual, utils.Local)
 		if err != nil {
 			log.Error("failed getting repositories list: " + err.Error())
 			// Continue without auto complete.
@@ -504,7 +522,7 @@ func (configFile *ConfigFile) setResolver(withSnapshot bool) error {
 			// To resolve dependencies from a Remote Go repository, you must nest the remote repository under a virtual Go repository.
 			repoTypes = append(repoTypes, utils.Remote)
 		}
-		resolverRepos, err := getRepositories(configFile.Resolver.ServerId, repoTypes...)
+		resolverRepos, err := getRepositories(configFile.Resolver.ServerId, projectTypeToPackageType[configFile.ConfigType], repoTypes...)
 		if err != nil {
 			log.Error("failed getting repositories list: " + err.Error())
 			// Continue without auto complete.
@@ -572,10 +590,16 @@ func (configFile *ConfigFile) setUseNugetV2() {
 	configFile.Resolver.NugetV2 = coreutils.AskYesNo("Use NuGet V2 Protocol?", false)
 }
 
-func validateRepositoryConfig(repository *project.Repository, errorPrefix string) error {
+func validateRepositoryConfig(repository *project.Repository, errorPrefix, configType string, isSupported bool) error {
 	releaseRepo := repository.ReleaseRepo
 	snapshotRepo := repository.SnapshotRepo
 
+	if !isSupported {
+		if repository.ServerId != "" || repository.Repo != "" || releaseRepo != "" || snapshotRepo != "" {
+			return errorutils.CheckErrorf(errorPrefix + fmt.Sprintf(unsupportedRepositoryError, configType))
+		}
+		return nil
+	}
 	if repository.ServerId != "" && repository.Repo == "" && releaseRepo == "" && snapshotRepo == "" {
 		return errorutils.CheckErrorf(errorPrefix + setRepositoryError)
 	}
@@ -614,11 +638,11 @@ func validateRepositoryConfig(repository *project.Repository, errorPrefix string
 
 // Validate spec file configuration
 func (configFile *ConfigFile) validateConfig() error {
-	err := validateRepositoryConfig(&configFile.Resolver, resolutionErrorPrefix)
+	err := validateRepositoryConfig(&configFile.Resolver, resolutionErrorPrefix, configFile.ConfigType, true)
 	if err != nil {
 		return err
 	}
-	return validateRepositoryConfig(&configFile.Deployer, deploymentErrorPrefix)
+	return validateRepositoryConfig(&configFile.Deployer, deploymentErrorPrefix, configFile.ConfigType, !slices.Contains(resolutionOnlyProjectTypes, configFile.ConfigType))
 }
 
 // Get Artifactory serverId from the user. If useArtifactoryQuestion is not empty, ask first whether to use artifactory.
@@ -659,7 +683,8 @@ func getServersIdAndDefault() ([]string, string, error) {
 	return serversId, defaultVal, nil
 }
 
-func getRepositories(serverId string, repoTypes ...utils.RepoType) ([]string, error) {
+// Get the repositories of the given types. If packageType is not empty, only repositories of this package type are returned.
+func getRepositories(serverId, packageType string, repoTypes ...utils.RepoType) ([]string, error) {
 	artDetails, err := config.GetSpecificConfig(serverId, false, true)
 	if err != nil {
 		return nil, err
@@ -671,7 +696,7 @@ func getRepositories(serverId string, repoTypes ...utils.RepoType) ([]string, er
 	}
 	repos := []string{}
 	for _, repoType := range repoTypes {
-		filteredRepos, err := utils.GetFilteredRepositoriesWithFilterParams(sm, nil, nil, services.RepositoriesFilterParams{RepoType: repoType.String()})
+		filteredRepos, err := utils.GetFilteredRepositoriesWithFilterParams(sm, nil, nil, services.RepositoriesFilterParams{RepoType: repoType.String(), PackageType: packageType})
 		if err != nil {
 			return nil, fmt.Errorf("failed getting %s repositories list: %w", repoType.String(), err)
 		}
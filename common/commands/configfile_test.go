@@ -2,6 +2,7 @@ package commands
 
 import (
 	"flag"
+	"fmt"
 	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
 	"os"
 	"path/filepath"
@@ -337,6 +338,47 @@ func TestGradleConfigFileDefaultPatterns(t *testing.T) {
 	assert.Equal(t, true, config.GetBool("useWrapper"))
 }
 
+func TestAdditionalProjectTypesConfigFile(t *testing.T) {
+	for _, projectType := range []project.ProjectType{project.Cargo, project.Conan, project.Helm, project.Sbt} {
+		t.Run(projectType.String(), func(t *testing.T) {
+			// Set JFROG_CLI_HOME_DIR environment variable
+			tempDirPath := createTempEnv(t)
+			defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+			// Create build config
+			context := createContext(t, resolutionServerId+"=relServer", resolutionRepo+"=repo", deploymentServerId+"=depServer", deploymentRepo+"=repo-local")
+			assert.NoError(t, CreateBuildConfig(context, projectType))
+
+			// Check configuration
+			config := checkCommonAndGetConfiguration(t, projectType.String(), tempDirPath)
+			assert.Equal(t, "relServer", config.GetString("resolver.serverId"))
+			assert.Equal(t, "repo", config.GetString("resolver.repo"))
+			assert.Equal(t, "depServer", config.GetString("deployer.serverId"))
+			assert.Equal(t, "repo-local", config.GetString("deployer.repo"))
+		})
+	}
+}
+
+func TestComposerConfigFile(t *testing.T) {
+	// Set JFROG_CLI_HOME_DIR environment variable
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	// Composer projects don't support deployment
+	context := createContext(t, resolutionServerId+"=relServer", resolutionRepo+"=repo", deploymentServerId+"=depServer", deploymentRepo+"=repo-local")
+	assert.EqualError(t, CreateBuildConfig(context, project.Composer), deploymentErrorPrefix+fmt.Sprintf(unsupportedRepositoryError, project.Composer.String()))
+
+	// Create build config
+	context = createContext(t, resolutionServerId+"=relServer", resolutionRepo+"=repo")
+	assert.NoError(t, CreateBuildConfig(context, project.Composer))
+
+	// Check configuration
+	config := checkCommonAndGetConfiguration(t, project.Composer.String(), tempDirPath)
+	assert.Equal(t, "relServer", config.GetString("resolver.serverId"))
+	assert.Equal(t, "repo", config.GetString("resolver.repo"))
+	assert.False(t, config.IsSet("deployer"))
+}
+
 func TestValidateConfigResolver(t *testing.T) {
 	// Create and check empty config
 	tempDirPath := createTempEnv(t)
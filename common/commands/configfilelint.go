@@ -0,0 +1,462 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+type LintLevel string
+
+const (
+	LintError   LintLevel = "error"
+	LintWarning LintLevel = "warning"
+	LintNote    LintLevel = "note"
+)
+
+// Lint rules IDs, used as the SARIF rule IDs.
+const (
+	invalidYamlRule         = "invalid-yaml"
+	configVersionRule       = "config-version"
+	configTypeRule          = "config-type"
+	serverIdRule            = "server-id"
+	repositoryExistsRule    = "repository-exists"
+	repositoryPackageRule   = "repository-package-type"
+	invalidPatternRule      = "invalid-pattern"
+	invalidPromotionRule    = "invalid-promotion"
+	sarifSchemaUri          = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion            = "2.1.0"
+	configFileLintToolName  = "jfrog-config-lint"
+	configFileLintInfoUri   = "https://docs.jfrog-applications.jfrog.io/jfrog-applications/jfrog-cli"
+	projectConfigFilesGlob  = "*.yaml"
+	projectConfigsDirectory = "projects"
+)
+
+// The expected repositories package types of project types which are not filtered by their package type during the interactive configuration.
+// See projectTypeToPackageType for the rest of the project types.
+var lintPackageTypes = map[string][]string{
+	project.Go.String():        {"go"},
+	project.Pip.String():       {"pypi"},
+	project.Pipenv.String():    {"pypi"},
+	project.Poetry.String():    {"pypi"},
+	project.Twine.String():     {"pypi"},
+	project.Npm.String():       {"npm"},
+	project.Pnpm.String():      {"npm"},
+	project.Yarn.String():      {"npm"},
+	project.Nuget.String():     {"nuget"},
+	project.Dotnet.String():    {"nuget"},
+	project.Maven.String():     {"maven"},
+	project.Gradle.String():    {"gradle", "maven", "ivy"},
+	project.Terraform.String(): {"terraform"},
+	project.Cocoapods.String(): {"cocoapods"},
+	project.Swift.String():     {"swift"},
+	project.Docker.String():    {"docker", "oci"},
+	project.Podman.String():    {"docker", "oci"},
+}
+
+type ConfigLintIssue struct {
+	FilePath string    `json:"filePath"`
+	Line     int       `json:"line,omitempty"`
+	RuleId   string    `json:"ruleId"`
+	Level    LintLevel `json:"level"`
+	Message  string    `json:"message"`
+}
+
+type configLintTableRow struct {
+	FilePath string `col-name:"File"`
+	Line     string `col-name:"Line"`
+	Level    string `col-name:"Level"`
+	RuleId   string `col-name:"Rule"`
+	Message  string `col-name:"Message"`
+}
+
+// ConfigFileLintCommand validates all the project config files (.jfrog/projects/*.yaml) of a project.
+type ConfigFileLintCommand struct {
+	projectDir   string
+	outputFormat format.OutputFormat
+	issues       []ConfigLintIssue
+	// Services managers by server ID, to avoid creating a manager per repository.
+	servicesManagers map[string]artifactory.ArtifactoryServicesManager
+}
+
+func NewConfigFileLintCommand() *ConfigFileLintCommand {
+	return &ConfigFileLintCommand{outputFormat: format.Table, servicesManagers: make(map[string]artifactory.ArtifactoryServicesManager)}
+}
+
+// Set the directory containing the '.jfrog' directory. If not set, the '.jfrog' directory is searched upstream from the working directory.
+func (cflc *ConfigFileLintCommand) SetProjectDir(projectDir string) *ConfigFileLintCommand {
+	cflc.projectDir = projectDir
+	return cflc
+}
+
+func (cflc *ConfigFileLintCommand) SetOutputFormat(outputFormat format.OutputFormat) *ConfigFileLintCommand {
+	cflc.outputFormat = outputFormat
+	return cflc
+}
+
+func (cflc *ConfigFileLintCommand) Issues() []ConfigLintIssue {
+	return cflc.issues
+}
+
+func (cflc *ConfigFileLintCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (cflc *ConfigFileLintCommand) CommandName() string {
+	return "config_lint"
+}
+
+func (cflc *ConfigFileLintCommand) Run() error {
+	configFiles, err := cflc.getConfigFiles()
+	if err != nil {
+		return err
+	}
+	if len(configFiles) == 0 {
+		log.Info("No project config files were found.")
+	}
+	for _, configFile := range configFiles {
+		cflc.lintConfigFile(configFile)
+	}
+	if err = cflc.printIssues(); err != nil {
+		return err
+	}
+	errorsCount := 0
+	for _, issue := range cflc.issues {
+		if issue.Level == LintError {
+			errorsCount++
+		}
+	}
+	if errorsCount > 0 {
+		return errorutils.CheckErrorf("found %d errors in the project config files", errorsCount)
+	}
+	return nil
+}
+
+func (cflc *ConfigFileLintCommand) getConfigFiles() ([]string, error) {
+	if cflc.projectDir == "" {
+		projectDir, exists, err := fileutils.FindUpstream(".jfrog", fileutils.Dir)
+		if err != nil || !exists {
+			return nil, err
+		}
+		cflc.projectDir = projectDir
+	}
+	configFiles, err := filepath.Glob(filepath.Join(cflc.projectDir, ".jfrog", projectConfigsDirectory, projectConfigFilesGlob))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	sort.Strings(configFiles)
+	return configFiles, nil
+}
+
+func (cflc *ConfigFileLintCommand) addIssue(filePath string, node *yaml.Node, ruleId string, level LintLevel, message string) {
+	// Report the paths relative to the project directory, so they can be matched with the files in code review tools.
+	if relativePath, err := filepath.Rel(cflc.projectDir, filePath); err == nil {
+		filePath = filepath.ToSlash(relativePath)
+	}
+	issue := ConfigLintIssue{FilePath: filePath, RuleId: ruleId, Level: level, Message: message}
+	if node != nil {
+		issue.Line = node.Line
+	}
+	cflc.issues = append(cflc.issues, issue)
+}
+
+func (cflc *ConfigFileLintCommand) lintConfigFile(filePath string) {
+	log.Debug("Linting the project config file", filePath)
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		cflc.addIssue(filePath, nil, invalidYamlRule, LintError, err.Error())
+		return
+	}
+	var root yaml.Node
+	configFile := new(ConfigFile)
+	if err = yaml.Unmarshal(content, &root); err == nil {
+		err = root.Decode(configFile)
+	}
+	if err != nil {
+		cflc.addIssue(filePath, nil, invalidYamlRule, LintError, "failed to parse the config file: "+err.Error())
+		return
+	}
+
+	if configFile.Version != BuildConfVersion {
+		cflc.addIssue(filePath, findYamlNode(&root, "version"), configVersionRule, LintError,
+			fmt.Sprintf("unsupported config version %d, expected version %d", configFile.Version, BuildConfVersion))
+	}
+	expectedType := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	switch {
+	case project.FromString(configFile.ConfigType) == -1:
+		cflc.addIssue(filePath, findYamlNode(&root, "type"), configTypeRule, LintError,
+			fmt.Sprintf("unknown project type '%s'", configFile.ConfigType))
+	case configFile.ConfigType != expectedType:
+		cflc.addIssue(filePath, findYamlNode(&root, "type"), configTypeRule, LintWarning,
+			fmt.Sprintf("the project type '%s' doesn't match the file name, the file is expected to be named %s.yaml", configFile.ConfigType, configFile.ConfigType))
+	}
+
+	cflc.lintRepository(filePath, &root, project.ProjectConfigResolverPrefix, &configFile.Resolver, configFile.ConfigType)
+	cflc.lintRepository(filePath, &root, project.ProjectConfigDeployerPrefix, &configFile.Deployer, configFile.ConfigType)
+	cflc.lintPromotion(filePath, &root)
+}
+
+func (cflc *ConfigFileLintCommand) lintRepository(filePath string, root *yaml.Node, prefix string, repository *project.Repository, configType string) {
+	// The patterns don't depend on the server, unlike the repositories, which are checked only if the server ID is configured.
+	cflc.lintPatterns(filePath, findYamlNode(root, prefix, "includePatterns"), repository.IncludePatterns)
+	cflc.lintPatterns(filePath, findYamlNode(root, prefix, "excludePatterns"), repository.ExcludePatterns)
+	if repository.ServerId == "" {
+		return
+	}
+	serverDetails, err := config.GetSpecificConfig(repository.ServerId, false, true)
+	if err != nil {
+		cflc.addIssue(filePath, findYamlNode(root, prefix, project.ProjectConfigServerId), serverIdRule, LintError,
+			fmt.Sprintf("the server ID '%s' is not configured. Use the 'jf c add' command to add it", repository.ServerId))
+		return
+	}
+	repositories := []struct{ key, yamlKey string }{
+		{repository.Repo, project.ProjectConfigRepo},
+		{repository.ReleaseRepo, project.ProjectConfigReleaseRepo},
+		{repository.SnapshotRepo, "snapshotRepo"},
+	}
+	for _, repo := range repositories {
+		if repo.key != "" {
+			cflc.lintRepositoryOnServer(filePath, findYamlNode(root, prefix, repo.yamlKey), serverDetails, repo.key, configType)
+		}
+	}
+}
+
+func (cflc *ConfigFileLintCommand) lintRepositoryOnServer(filePath string, node *yaml.Node, serverDetails *config.ServerDetails, repoKey, configType string) {
+	servicesManager, err := cflc.getServicesManager(serverDetails)
+	if err != nil {
+		cflc.addIssue(filePath, node, repositoryExistsRule, LintError, err.Error())
+		return
+	}
+	exists, err := servicesManager.IsRepoExists(repoKey)
+	if err != nil {
+		cflc.addIssue(filePath, node, repositoryExistsRule, LintWarning,
+			fmt.Sprintf("failed to check whether the repository '%s' exists on '%s': %s", repoKey, serverDetails.ServerId, err.Error()))
+		return
+	}
+	if !exists {
+		cflc.addIssue(filePath, node, repositoryExistsRule, LintError,
+			fmt.Sprintf("the repository '%s' doesn't exist on '%s'", repoKey, serverDetails.ServerId))
+		return
+	}
+	expectedPackageTypes := getExpectedPackageTypes(configType)
+	if len(expectedPackageTypes) == 0 {
+		return
+	}
+	repoDetails := services.RepositoryDetails{}
+	if err = servicesManager.GetRepository(repoKey, &repoDetails); err != nil {
+		cflc.addIssue(filePath, node, repositoryPackageRule, LintWarning,
+			fmt.Sprintf("failed to get the details of the repository '%s': %s", repoKey, err.Error()))
+		return
+	}
+	for _, packageType := range expectedPackageTypes {
+		if strings.EqualFold(repoDetails.PackageType, packageType) {
+			return
+		}
+	}
+	cflc.addIssue(filePath, node, repositoryPackageRule, LintError,
+		fmt.Sprintf("the repository '%s' is of package type '%s', while %s projects require the package type: %s",
+			repoKey, repoDetails.PackageType, configType, coreutils.ListToText(expectedPackageTypes)))
+}
+
+func (cflc *ConfigFileLintCommand) getServicesManager(serverDetails *config.ServerDetails) (servicesManager artifactory.ArtifactoryServicesManager, err error) {
+	if servicesManager = cflc.servicesManagers[serverDetails.ServerId]; servicesManager != nil {
+		return
+	}
+	if servicesManager, err = utils.CreateServiceManager(serverDetails, 0, 0, false); err != nil {
+		return
+	}
+	cflc.servicesManagers[serverDetails.ServerId] = servicesManager
+	return
+}
+
+// Include and exclude patterns are comma separated wildcard patterns.
+func (cflc *ConfigFileLintCommand) lintPatterns(filePath string, node *yaml.Node, patterns string) {
+	if patterns == "" {
+		return
+	}
+	for _, pattern := range strings.Split(patterns, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			cflc.addIssue(filePath, node, invalidPatternRule, LintError, fmt.Sprintf("the patterns '%s' contain an empty pattern", patterns))
+			continue
+		}
+		if strings.ContainsAny(pattern, "; \t") {
+			cflc.addIssue(filePath, node, invalidPatternRule, LintError,
+				fmt.Sprintf("the pattern '%s' contains a semicolon or a whitespace. Patterns should be separated by commas", pattern))
+			continue
+		}
+		if strings.Contains(pattern, "\\") {
+			cflc.addIssue(filePath, node, invalidPatternRule, LintWarning,
+				fmt.Sprintf("the pattern '%s' contains a backslash. Use '/' as the path separator", pattern))
+		}
+	}
+}
+
+func (cflc *ConfigFileLintCommand) lintPromotion(filePath string, root *yaml.Node) {
+	promotionNode := findYamlNode(root, project.ProjectConfigPromotion)
+	if promotionNode == nil {
+		return
+	}
+	promotionConfig := new(project.PromotionConfig)
+	if err := promotionNode.Decode(promotionConfig); err != nil {
+		cflc.addIssue(filePath, promotionNode, invalidPromotionRule, LintError, err.Error())
+		return
+	}
+	if err := promotionConfig.Validate(); err != nil {
+		cflc.addIssue(filePath, promotionNode, invalidPromotionRule, LintError, err.Error())
+	}
+}
+
+func getExpectedPackageTypes(configType string) []string {
+	if packageType, ok := projectTypeToPackageType[configType]; ok {
+		return []string{packageType}
+	}
+	return lintPackageTypes[configType]
+}
+
+// Return the value node of the given keys path, or nil if not found.
+func findYamlNode(node *yaml.Node, keys ...string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, key := range keys {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var value *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				value = node.Content[i+1]
+				break
+			}
+		}
+		if value == nil {
+			return nil
+		}
+		node = value
+	}
+	return node
+}
+
+func (cflc *ConfigFileLintCommand) printIssues() error {
+	switch cflc.outputFormat {
+	case format.Json, format.SimpleJson:
+		return printJson(cflc.issues)
+	case format.Sarif:
+		return printJson(createConfigLintSarifReport(cflc.issues))
+	default:
+		rows := make([]configLintTableRow, 0, len(cflc.issues))
+		for _, issue := range cflc.issues {
+			line := ""
+			if issue.Line > 0 {
+				line = strconv.Itoa(issue.Line)
+			}
+			rows = append(rows, configLintTableRow{FilePath: issue.FilePath, Line: line, Level: string(issue.Level), RuleId: issue.RuleId, Message: issue.Message})
+		}
+		return coreutils.PrintTable(rows, "Project Config Files Issues", "No issues were found in the project config files", false)
+	}
+}
+
+func printJson(output any) error {
+	content, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(string(content))
+	return nil
+}
+
+// Minimal SARIF 2.1.0 model, containing the fields required for displaying the issues in code review tools.
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+func createConfigLintSarifReport(issues []ConfigLintIssue) *sarifReport {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: configFileLintToolName, InformationUri: configFileLintInfoUri, Rules: []sarifRule{}}},
+		Results: []sarifResult{},
+	}
+	rules := make(map[string]bool)
+	for _, issue := range issues {
+		if !rules[issue.RuleId] {
+			rules[issue.RuleId] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{Id: issue.RuleId})
+		}
+		location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{Uri: issue.FilePath}}}
+		if issue.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: issue.Line}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleId:    issue.RuleId,
+			Level:     string(issue.Level),
+			Message:   sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+	return &sarifReport{Schema: sarifSchemaUri, Version: sarifVersion, Runs: []sarifRun{run}}
+}
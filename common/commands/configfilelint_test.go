@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	validNpmConfig = `version: 1
+type: npm
+resolver:
+  repo: npm-virtual
+  serverId: lint-server
+deployer:
+  repo: npm-local
+  serverId: lint-server
+`
+	invalidMavenConfig = `version: 2
+type: maven
+resolver:
+  releaseRepo: npm-virtual
+  snapshotRepo: missing-repo
+  serverId: lint-server
+deployer:
+  releaseRepo: maven-local
+  snapshotRepo: maven-local
+  serverId: missing-server
+  includePatterns: "*.jar, ,*-sources.jar"
+`
+)
+
+func TestConfigFileLint(t *testing.T) {
+	// Set JFROG_CLI_HOME_DIR environment variable
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	testServer := tests.CreateRestsMockServer(func(w http.ResponseWriter, r *http.Request) {
+		repoKey := strings.TrimPrefix(r.URL.Path, "/api/repositories/")
+		packageType := map[string]string{"npm-virtual": "npm", "npm-local": "npm", "maven-local": "maven"}[repoKey]
+		if packageType == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		content, err := json.Marshal(services.RepositoryDetails{Key: repoKey, PackageType: packageType})
+		assert.NoError(t, err)
+		_, err = w.Write(content)
+		assert.NoError(t, err)
+	})
+	defer testServer.Close()
+	assert.NoError(t, config.SaveServersConf([]*config.ServerDetails{{ServerId: "lint-server", Url: testServer.URL + "/", ArtifactoryUrl: testServer.URL + "/"}}))
+
+	projectDir := t.TempDir()
+	projectsDir := filepath.Join(projectDir, ".jfrog", "projects")
+	assert.NoError(t, os.MkdirAll(projectsDir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(projectsDir, "npm.yaml"), []byte(validNpmConfig), 0644))
+
+	// Valid config file
+	lintCommand := NewConfigFileLintCommand().SetProjectDir(projectDir).SetOutputFormat(format.Json)
+	assert.NoError(t, lintCommand.Run())
+	assert.Empty(t, lintCommand.Issues())
+
+	// Invalid config file
+	assert.NoError(t, os.WriteFile(filepath.Join(projectsDir, "mvn.yaml"), []byte(invalidMavenConfig), 0644))
+	lintCommand = NewConfigFileLintCommand().SetProjectDir(projectDir).SetOutputFormat(format.Sarif)
+	assert.ErrorContains(t, lintCommand.Run(), "found 5 errors")
+	expectedIssues := []ConfigLintIssue{
+		{FilePath: ".jfrog/projects/mvn.yaml", Line: 1, RuleId: configVersionRule, Level: LintError},
+		{FilePath: ".jfrog/projects/mvn.yaml", Line: 2, RuleId: configTypeRule, Level: LintWarning},
+		{FilePath: ".jfrog/projects/mvn.yaml", Line: 4, RuleId: repositoryPackageRule, Level: LintError},
+		{FilePath: ".jfrog/projects/mvn.yaml", Line: 5, RuleId: repositoryExistsRule, Level: LintError},
+		{FilePath: ".jfrog/projects/mvn.yaml", Line: 11, RuleId: invalidPatternRule, Level: LintError},
+		{FilePath: ".jfrog/projects/mvn.yaml", Line: 10, RuleId: serverIdRule, Level: LintError},
+	}
+	actualIssues := lintCommand.Issues()
+	if assert.Len(t, actualIssues, len(expectedIssues)) {
+		for i, expected := range expectedIssues {
+			expected.Message = actualIssues[i].Message
+			assert.Equal(t, expected, actualIssues[i])
+		}
+		// The patterns are checked, although the server ID isn't configured
+		assert.Equal(t, "the patterns '*.jar, ,*-sources.jar' contain an empty pattern", actualIssues[4].Message)
+	}
+}
+
+func TestConfigFileLintPatterns(t *testing.T) {
+	testCases := []struct {
+		name            string
+		patterns        string
+		expectedLevel   LintLevel
+		expectedMessage string
+	}{
+		{"valid", "*.jar, lib/*-sources.jar", "", ""},
+		{"empty pattern", "*.jar, ,*-sources.jar", LintError, "empty pattern"},
+		{"semicolon separator", "*.jar;*.pom", LintError, "separated by commas"},
+		{"whitespace separator", "*.jar *.pom", LintError, "separated by commas"},
+		{"backslash separator", `lib\*.jar`, LintWarning, "Use '/' as the path separator"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			lintCommand := NewConfigFileLintCommand().SetProjectDir("project")
+			lintCommand.lintPatterns(filepath.Join("project", "maven.yaml"), nil, testCase.patterns)
+			if testCase.expectedMessage == "" {
+				assert.Empty(t, lintCommand.Issues())
+				return
+			}
+			if assert.Len(t, lintCommand.Issues(), 1) {
+				assert.Equal(t, invalidPatternRule, lintCommand.Issues()[0].RuleId)
+				assert.Equal(t, testCase.expectedLevel, lintCommand.Issues()[0].Level)
+				assert.Contains(t, lintCommand.Issues()[0].Message, testCase.expectedMessage)
+			}
+		})
+	}
+}
+
+func TestCreateConfigLintSarifReport(t *testing.T) {
+	report := createConfigLintSarifReport([]ConfigLintIssue{
+		{FilePath: ".jfrog/projects/go.yaml", Line: 3, RuleId: serverIdRule, Level: LintError, Message: "msg1"},
+		{FilePath: ".jfrog/projects/npm.yaml", RuleId: serverIdRule, Level: LintWarning, Message: "msg2"},
+	})
+	assert.Equal(t, sarifVersion, report.Version)
+	if assert.Len(t, report.Runs, 1) {
+		run := report.Runs[0]
+		assert.Equal(t, []sarifRule{{Id: serverIdRule}}, run.Tool.Driver.Rules)
+		if assert.Len(t, run.Results, 2) {
+			assert.Equal(t, ".jfrog/projects/go.yaml", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.Uri)
+			assert.Equal(t, &sarifRegion{StartLine: 3}, run.Results[0].Locations[0].PhysicalLocation.Region)
+			assert.Nil(t, run.Results[1].Locations[0].PhysicalLocation.Region)
+			assert.Equal(t, "warning", run.Results[1].Level)
+		}
+	}
+}
@@ -45,6 +45,11 @@ const (
 	Docker
 	Podman
 	Twine
+	Cargo
+	Conan
+	Helm
+	Composer
+	Sbt
 )
 
 type ConfigType string
@@ -73,6 +78,11 @@ var ProjectTypes = []string{
 	"docker",
 	"podman",
 	"twine",
+	"cargo",
+	"conan",
+	"helm",
+	"composer",
+	"sbt",
 }
 
 func (projectType ProjectType) String() string {
@@ -15,6 +15,11 @@ func TestFromString(t *testing.T) {
 		{"pip", Pip},
 		{"npm", Npm},
 		{"pnpm", Pnpm},
+		{"cargo", Cargo},
+		{"conan", Conan},
+		{"helm", Helm},
+		{"composer", Composer},
+		{"sbt", Sbt},
 	}
 
 	for _, testCase := range testCases {
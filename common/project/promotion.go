@@ -0,0 +1,127 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+const ProjectConfigPromotion = "promotion"
+
+// PromotionConfig is the 'promotion' section of a project config file.
+// It describes the ordered stages a build goes through, for example: dev -> staging -> release.
+type PromotionConfig struct {
+	ServerId string           `yaml:"serverId,omitempty"`
+	Stages   []PromotionStage `yaml:"stages,omitempty"`
+	// Server details, resolved from ServerId after reading the config file, unless explicit server details are given.
+	serverDetails *config.ServerDetails
+}
+
+type PromotionStage struct {
+	Name                string `yaml:"name,omitempty"`
+	SourceRepo          string `yaml:"sourceRepo,omitempty"`
+	TargetRepo          string `yaml:"targetRepo,omitempty"`
+	Status              string `yaml:"status,omitempty"`
+	Comment             string `yaml:"comment,omitempty"`
+	Copy                bool   `yaml:"copy,omitempty"`
+	IncludeDependencies bool   `yaml:"includeDependencies,omitempty"`
+	// Properties to set on the promoted artifacts, in the form of "key1=value1;key2=value2".
+	Properties string `yaml:"properties,omitempty"`
+	// Keys of properties which must be collected in the local build-info before it can be promoted.
+	RequiredProperties []string `yaml:"requiredProperties,omitempty"`
+}
+
+// Read the 'promotion' section from the project config file and resolve its server details.
+// The given server details take precedence over the server ID of the section, which is then not resolved.
+// If neither is set, the default configured server is used.
+func GetPromotionConfig(configFilePath string, vConfig *viper.Viper, serverDetails *config.ServerDetails) (promotionConfig *PromotionConfig, err error) {
+	if !vConfig.IsSet(ProjectConfigPromotion) {
+		return nil, errorutils.CheckErrorf("the %s section is missing from the config file (%s)", ProjectConfigPromotion, configFilePath)
+	}
+	log.Debug(fmt.Sprintf("Found %s in the config file %s", ProjectConfigPromotion, configFilePath))
+	promotionConfig = new(PromotionConfig)
+	if err = vConfig.UnmarshalKey(ProjectConfigPromotion, promotionConfig); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the %s section within %s: %s", ProjectConfigPromotion, configFilePath, err.Error())
+	}
+	if err = promotionConfig.Validate(); err != nil {
+		return nil, errorutils.CheckErrorf("invalid %s section within %s: %s", ProjectConfigPromotion, configFilePath, err.Error())
+	}
+	if serverDetails != nil {
+		promotionConfig.serverDetails = serverDetails
+		return
+	}
+	// A server ID set in the section must be configured
+	promotionConfig.serverDetails, err = config.GetSpecificConfig(promotionConfig.ServerId, promotionConfig.ServerId == "", true)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Read the 'promotion' section from the project config file at the given path.
+// serverDetails - Optional server details, which take precedence over the server ID of the section.
+func ReadPromotionConfiguration(confFilePath string, serverDetails *config.ServerDetails) (*PromotionConfig, error) {
+	log.Debug("Preparing to read the config file", confFilePath)
+	vConfig, err := ReadConfigFile(confFilePath, YAML)
+	if err != nil {
+		return nil, err
+	}
+	return GetPromotionConfig(confFilePath, vConfig, serverDetails)
+}
+
+func (pc *PromotionConfig) Validate() error {
+	if len(pc.Stages) == 0 {
+		return fmt.Errorf("at least one promotion stage must be defined")
+	}
+	names := make(map[string]bool, len(pc.Stages))
+	for i, stage := range pc.Stages {
+		if stage.Name == "" {
+			return fmt.Errorf("the name of stage %d is missing", i)
+		}
+		if names[stage.Name] {
+			return fmt.Errorf("the stage '%s' is defined more than once", stage.Name)
+		}
+		names[stage.Name] = true
+		if stage.TargetRepo == "" {
+			return fmt.Errorf("the target repository of stage '%s' is missing", stage.Name)
+		}
+		if stage.SourceRepo != "" && stage.SourceRepo == stage.TargetRepo {
+			return fmt.Errorf("the source and target repositories of stage '%s' must be different", stage.Name)
+		}
+		for _, prop := range strings.Split(stage.Properties, ";") {
+			if prop != "" && !strings.Contains(prop, "=") {
+				return fmt.Errorf("the property '%s' of stage '%s' must be in the form of key=value", prop, stage.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Return the stage with the given name. If the name is empty and only a single stage is defined, this stage is returned.
+func (pc *PromotionConfig) GetStage(name string) (*PromotionStage, error) {
+	if name == "" {
+		if len(pc.Stages) == 1 {
+			return &pc.Stages[0], nil
+		}
+		return nil, errorutils.CheckErrorf("multiple promotion stages are defined, please specify the stage to promote to")
+	}
+	for i := range pc.Stages {
+		if pc.Stages[i].Name == name {
+			return &pc.Stages[i], nil
+		}
+	}
+	return nil, errorutils.CheckErrorf("the promotion stage '%s' is not defined in the project config file", name)
+}
+
+func (pc *PromotionConfig) SetServerDetails(serverDetails *config.ServerDetails) *PromotionConfig {
+	pc.serverDetails = serverDetails
+	return pc
+}
+
+func (pc *PromotionConfig) ServerDetails() *config.ServerDetails {
+	return pc.serverDetails
+}
@@ -0,0 +1,105 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPromotionConfiguration(t *testing.T) {
+	cleanUpJfrogHome, err := tests.SetJfrogHome()
+	assert.NoError(t, err)
+	defer cleanUpJfrogHome()
+
+	promotionConfig, err := ReadPromotionConfiguration(filepath.Join("testdata", "build-promotion.yaml"), nil)
+	assert.NoError(t, err)
+	assert.Len(t, promotionConfig.Stages, 2)
+	assert.NotNil(t, promotionConfig.ServerDetails())
+
+	staging, err := promotionConfig.GetStage("staging")
+	assert.NoError(t, err)
+	assert.Equal(t, PromotionStage{
+		Name:               "staging",
+		SourceRepo:         "libs-dev-local",
+		TargetRepo:         "libs-staging-local",
+		Status:             "staged",
+		Copy:               true,
+		RequiredProperties: []string{"buildInfo.env.TESTS_PASSED"},
+	}, *staging)
+
+	release, err := promotionConfig.GetStage("release")
+	assert.NoError(t, err)
+	assert.True(t, release.IncludeDependencies)
+	assert.False(t, release.Copy)
+	assert.Equal(t, "released=true;stage=release", release.Properties)
+
+	_, err = promotionConfig.GetStage("")
+	assert.ErrorContains(t, err, "multiple promotion stages are defined")
+	_, err = promotionConfig.GetStage("prod")
+	assert.ErrorContains(t, err, "'prod' is not defined")
+}
+
+func TestReadPromotionConfigurationWithServerDetails(t *testing.T) {
+	// The server details aren't resolved from the empty JFrog home
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	serverDetails := &config.ServerDetails{ServerId: "explicit", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}
+	promotionConfig, err := ReadPromotionConfiguration(filepath.Join("testdata", "build-promotion.yaml"), serverDetails)
+	assert.NoError(t, err)
+	assert.Same(t, serverDetails, promotionConfig.ServerDetails())
+}
+
+func TestReadPromotionConfigurationWithServerDetailsAndServerId(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	content, err := os.ReadFile(filepath.Join("testdata", "build-promotion.yaml"))
+	assert.NoError(t, err)
+	configFilePath := filepath.Join(t.TempDir(), "build.yaml")
+	// The server ID isn't configured, but isn't resolved either, because the explicit server details take precedence
+	content = []byte(strings.Replace(string(content), "promotion:\n", "promotion:\n  serverId: not-configured\n", 1))
+	assert.NoError(t, os.WriteFile(configFilePath, content, 0644))
+
+	serverDetails := &config.ServerDetails{ServerId: "explicit", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}
+	promotionConfig, err := ReadPromotionConfiguration(configFilePath, serverDetails)
+	assert.NoError(t, err)
+	assert.Equal(t, "not-configured", promotionConfig.ServerId)
+	assert.Same(t, serverDetails, promotionConfig.ServerDetails())
+
+	_, err = ReadPromotionConfiguration(configFilePath, nil)
+	assert.ErrorContains(t, err, "not-configured")
+}
+
+func TestReadPromotionConfigurationMissingSection(t *testing.T) {
+	_, err := ReadPromotionConfiguration(filepath.Join("..", "build", "testdata", "build.yaml"), nil)
+	assert.ErrorContains(t, err, "the promotion section is missing")
+}
+
+func TestPromotionConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		stages        []PromotionStage
+		expectedError string
+	}{
+		{"valid", []PromotionStage{{Name: "a", TargetRepo: "b"}}, ""},
+		{"noStages", nil, "at least one promotion stage"},
+		{"missingName", []PromotionStage{{TargetRepo: "b"}}, "the name of stage 0 is missing"},
+		{"duplicateName", []PromotionStage{{Name: "a", TargetRepo: "b"}, {Name: "a", TargetRepo: "c"}}, "defined more than once"},
+		{"missingTarget", []PromotionStage{{Name: "a"}}, "target repository of stage 'a' is missing"},
+		{"sameSourceAndTarget", []PromotionStage{{Name: "a", SourceRepo: "b", TargetRepo: "b"}}, "must be different"},
+		{"invalidProperties", []PromotionStage{{Name: "a", TargetRepo: "b", Properties: "k=v;invalid"}}, "'invalid' of stage 'a'"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := (&PromotionConfig{Stages: testCase.stages}).Validate()
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	jsonSchemaDraft  = "http://json-schema.org/draft-07/schema#"
+	fileSpecSchemaId = "https://github.com/jfrog/jfrog-cli-core/blob/master/common/spec/schema/filespec-schema.json"
+	fileDefinitionId = "#/definitions/file"
+	specFilesKey     = "files"
+	specDefaultsKey  = "defaults"
+	specFragmentsKey = "fragments"
+)
+
+// Fields of spec.File, which are strings holding a boolean value.
+var booleanSpecFields = map[string]bool{
+	"Explode": true, "BypassArchiveInspection": true, "ExcludeArtifacts": true, "IncludeDeps": true, "Recursive": true,
+	"Flat": true, "Regexp": true, "Ant": true, "IncludeDirs": true, "ValidateSymlinks": true, "Symlinks": true, "Transitive": true,
+}
+
+// Schemas of spec.File fields, which can't be generated from their Go type.
+var specFieldSchemaOverrides = map[string]map[string]any{
+	"Aql": {
+		"type":                 "object",
+		"properties":           map[string]any{"items.find": map[string]any{"type": "object"}},
+		"required":             []string{"items.find"},
+		"additionalProperties": false,
+	},
+	"SortOrder": {"type": "string", "enum": []string{"asc", "desc"}},
+	"Archive":   {"type": "string", "enum": []string{"zip"}},
+}
+
+type specField struct {
+	// The Go field name
+	name string
+	// The field name in the spec file
+	specName  string
+	fieldType reflect.Type
+}
+
+// Return the exported fields of spec.File, as they appear in spec files.
+func getSpecFields() (fields []specField) {
+	fileType := reflect.TypeOf(File{})
+	for i := 0; i < fileType.NumField(); i++ {
+		field := fileType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fields = append(fields, specField{name: field.Name, specName: getSpecFieldName(field), fieldType: field.Type})
+	}
+	return
+}
+
+func getSpecFieldName(field reflect.StructField) string {
+	if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" {
+		return tag
+	}
+	nameRunes := []rune(field.Name)
+	nameRunes[0] = unicode.ToLower(nameRunes[0])
+	return string(nameRunes)
+}
+
+// GenerateFileSpecSchema generates the JSON Schema of File Specs from the spec.File struct.
+func GenerateFileSpecSchema() ([]byte, error) {
+	fileProperties := make(map[string]any)
+	for _, field := range getSpecFields() {
+		fileProperties[field.specName] = getFieldSchema(field)
+	}
+	fileDefinition := map[string]any{"$ref": fileDefinitionId}
+	schema := map[string]any{
+		"$schema":     jsonSchemaDraft,
+		"$id":         fileSpecSchemaId,
+		"title":       "JFrog File Spec",
+		"description": "File Specs are used to specify the files for JFrog CLI commands, such as upload, download, copy, move, delete and search.",
+		"type":        "object",
+		"properties": map[string]any{
+			specFilesKey:     map[string]any{"type": "array", "items": fileDefinition},
+			specDefaultsKey:  fileDefinition,
+			specFragmentsKey: map[string]any{"type": "object", "additionalProperties": fileDefinition},
+		},
+		"required":             []string{specFilesKey},
+		"additionalProperties": false,
+		"definitions": map[string]any{
+			"file": map[string]any{"type": "object", "properties": fileProperties, "additionalProperties": false},
+		},
+	}
+	content, err := json.MarshalIndent(schema, "", "  ")
+	return content, errorutils.CheckError(err)
+}
+
+func getFieldSchema(field specField) map[string]any {
+	if override, ok := specFieldSchemaOverrides[field.name]; ok {
+		return override
+	}
+	if booleanSpecFields[field.name] {
+		return map[string]any{"type": "string", "enum": []string{"true", "false"}}
+	}
+	return getTypeSchema(field.fieldType)
+}
+
+func getTypeSchema(fieldType reflect.Type) map[string]any {
+	switch fieldType.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Int:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": getTypeSchema(fieldType.Elem())}
+	case reflect.Struct:
+		properties := make(map[string]any)
+		for i := 0; i < fieldType.NumField(); i++ {
+			field := fieldType.Field(i)
+			properties[getSpecFieldName(field)] = getTypeSchema(field.Type)
+		}
+		return map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+	default:
+		return map[string]any{}
+	}
+}
+
+func isYamlSpec(specFilePath string) bool {
+	extension := strings.ToLower(filepath.Ext(specFilePath))
+	return extension == ".yaml" || extension == ".yml"
+}
+
+// Convert the content of a YAML spec file to JSON.
+// Scalar values of string fields are converted to strings, to allow writing 'flat: true' instead of 'flat: "true"'.
+func convertYamlSpecToJson(content []byte) ([]byte, error) {
+	var spec any
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the YAML spec: %s", err.Error())
+	}
+	specMap, ok := spec.(map[string]any)
+	if !ok {
+		return nil, errorutils.CheckErrorf("the YAML spec must be a mapping containing the '%s' key", specFilesKey)
+	}
+	forEachSpecFile(specMap, func(_ string, file map[string]any) {
+		for _, field := range getSpecFields() {
+			if field.fieldType.Kind() != reflect.String {
+				continue
+			}
+			for key, value := range file {
+				if strings.EqualFold(key, field.specName) {
+					if _, isString := value.(string); !isString && value != nil {
+						file[key] = fmt.Sprint(value)
+					}
+				}
+			}
+		}
+	})
+	content, err := json.Marshal(specMap)
+	return content, errorutils.CheckError(err)
+}
+
+// Return an error if the spec contains fields unknown to spec.File.
+// Field names are matched case-insensitively, in the same way they are matched when unmarshalling the spec.
+func validateSpecFields(content []byte) error {
+	var spec map[string]any
+	if err := json.Unmarshal(content, &spec); err != nil {
+		return errorutils.CheckErrorf("failed to parse the spec: %s", err.Error())
+	}
+	for _, key := range getSortedKeys(spec) {
+		if !containsFold([]string{specFilesKey, specDefaultsKey, specFragmentsKey}, key) {
+			return errorutils.CheckErrorf("unknown field '%s' in the spec. The spec may only contain the fields: %s, %s and %s", key, specFilesKey, specDefaultsKey, specFragmentsKey)
+		}
+	}
+	var fieldNames []string
+	for _, field := range getSpecFields() {
+		fieldNames = append(fieldNames, field.specName)
+	}
+	var err error
+	forEachSpecFile(spec, func(location string, file map[string]any) {
+		if err != nil {
+			return
+		}
+		for _, key := range getSortedKeys(file) {
+			if !containsFold(fieldNames, key) {
+				message := fmt.Sprintf("%s: unknown field '%s'", location, key)
+				if suggestion := getClosestFieldName(key, fieldNames); suggestion != "" {
+					message += fmt.Sprintf(". Did you mean '%s'?", suggestion)
+				}
+				err = errorutils.CheckErrorf(message)
+				return
+			}
+		}
+	})
+	return err
+}
+
+// Run the given function on the files, the defaults and the fragments of the spec, with their location in the spec.
+// The keys are visited in a sorted order, so that errors are reported deterministically.
+func forEachSpecFile(spec map[string]any, fileFunc func(location string, file map[string]any)) {
+	for _, key := range getSortedKeys(spec) {
+		switch strings.ToLower(key) {
+		case specFilesKey:
+			files, _ := spec[key].([]any)
+			for i, file := range files {
+				if fileMap, ok := file.(map[string]any); ok {
+					fileFunc(fmt.Sprintf("%s[%d]", specFilesKey, i), fileMap)
+				}
+			}
+		case specDefaultsKey:
+			if fileMap, ok := spec[key].(map[string]any); ok {
+				fileFunc(specDefaultsKey, fileMap)
+			}
+		case specFragmentsKey:
+			fragments, _ := spec[key].(map[string]any)
+			for _, name := range getSortedKeys(fragments) {
+				if fileMap, ok := fragments[name].(map[string]any); ok {
+					fileFunc(fmt.Sprintf("%s.%s", specFragmentsKey, name), fileMap)
+				}
+			}
+		}
+	}
+}
+
+func getSortedKeys(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the field name closest to the given unknown field name, or an empty string if no field name is close enough.
+func getClosestFieldName(unknownField string, fieldNames []string) string {
+	const maxDistance = 2
+	closest, closestDistance := "", maxDistance+1
+	for _, fieldName := range fieldNames {
+		if distance := levenshteinDistance(strings.ToLower(unknownField), strings.ToLower(fieldName)); distance < closestDistance {
+			closest, closestDistance = fieldName, distance
+		}
+	}
+	return closest
+}
+
+func levenshteinDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,60 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSpecSchemaUpToDate(t *testing.T) {
+	expected, err := GenerateFileSpecSchema()
+	assert.NoError(t, err)
+	actual, err := os.ReadFile(filepath.Join("schema", "filespec-schema.json"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual), "the File Spec schema is outdated. Regenerate it using GenerateFileSpecSchema")
+}
+
+func TestCreateSpecFromYamlFile(t *testing.T) {
+	spec, err := CreateSpecFromFile(filepath.Join("testdata", "spec.yaml"), nil)
+	assert.NoError(t, err)
+	if assert.Len(t, spec.Files, 2) {
+		assert.Equal(t, "a/*.zip", spec.Files[0].Pattern)
+		assert.Equal(t, "generic-local/", spec.Files[0].Target)
+		assert.Equal(t, "true", spec.Files[0].Flat)
+		assert.Equal(t, "false", spec.Files[0].Recursive)
+		assert.Equal(t, 10, spec.Files[0].Limit)
+		assert.Equal(t, []string{"name"}, spec.Files[0].SortBy)
+		assert.Equal(t, `{"repo":"generic-local"}`, spec.Files[1].Aql.ItemsFind)
+	}
+}
+
+func TestCreateSpecFromFileUnknownField(t *testing.T) {
+	_, err := CreateSpecFromFile(filepath.Join("testdata", "unknown-field.json"), nil)
+	assert.EqualError(t, err, "files[1]: unknown field 'patern'. Did you mean 'pattern'?")
+}
+
+func TestValidateSpecFields(t *testing.T) {
+	testCases := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{"valid", `{"files": [{"pattern": "a", "Target": "b", "gpg-key": "c"}]}`, ""},
+		{"unknownRootField", `{"files": [], "file": []}`, "unknown field 'file' in the spec"},
+		{"unknownDefaultsField", `{"defaults": {"trget": "a"}, "files": []}`, "defaults: unknown field 'trget'. Did you mean 'target'?"},
+		{"unknownFragmentField", `{"fragments": {"a": {"xyz": "a"}}, "files": []}`, "fragments.a: unknown field 'xyz'"},
+		{"invalidJson", `{"files": [}`, "failed to parse the spec"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateSpecFields([]byte(testCase.content))
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
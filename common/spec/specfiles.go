@@ -11,6 +11,10 @@ import (
 
 type SpecFiles struct {
 	Files []File
+	// Fields inherited by all the files, unless set in the file itself.
+	Defaults *File `json:"defaults,omitempty"`
+	// Named groups of fields, which can be inherited by files that reference them.
+	Fragments map[string]File `json:"fragments,omitempty"`
 }
 
 func (spec *SpecFiles) Get(index int) *File {
@@ -20,6 +24,7 @@ func (spec *SpecFiles) Get(index int) *File {
 	return new(File)
 }
 
+// Create the spec from a JSON or a YAML (.yaml/.yml) File Spec.
 func CreateSpecFromFile(specFilePath string, specVars map[string]string) (spec *SpecFiles, err error) {
 	spec = new(SpecFiles)
 	content, err := fileutils.ReadFile(specFilePath)
@@ -30,11 +35,22 @@ func CreateSpecFromFile(specFilePath string, specVars map[string]string) (spec *
 	if len(specVars) > 0 {
 		content = coreutils.ReplaceVars(content, specVars)
 	}
-
+	if isYamlSpec(specFilePath) {
+		if content, err = convertYamlSpecToJson(content); err != nil {
+			return
+		}
+	}
+	if content, err = expandTemplateVars(content, specVars); err != nil {
+		return
+	}
+	if err = validateSpecFields(content); err != nil {
+		return
+	}
 	err = json.Unmarshal(content, spec)
 	if errorutils.CheckError(err) != nil {
 		return
 	}
+	err = spec.resolveInheritance()
 	return
 }
 
@@ -88,7 +104,9 @@ type File struct {
 	Symlinks                string
 	Transitive              string
 	TargetPathInArchive     string
-	include                 []string
+	// Names of the spec fragments inherited by this file.
+	Fragments []string `json:"fragments,omitempty"`
+	include   []string
 }
 
 func (f File) GetInclude() []string {
@@ -1,8 +1,12 @@
 package spec
 
 import (
-	"github.com/stretchr/testify/assert"
+	"encoding/json"
+	"path/filepath"
 	"testing"
+
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCreateSpecFromBuildNameAndNumber(t *testing.T) {
@@ -46,3 +50,85 @@ func TestCreateSpecFromBuildNameAndNumber(t *testing.T) {
 		assert.EqualError(t, err, "build name and build number must be provided")
 	})
 }
+
+func TestCreateSpecFromFileWithTemplates(t *testing.T) {
+	testsutils.UnSetEnvAndAssert(t, "TARGET_REPO")
+	spec, err := CreateSpecFromFile(filepath.Join("testdata", "templates.json"), map[string]string{"version": "1.0.0"})
+	assert.NoError(t, err)
+	assert.NoError(t, ValidateSpec(spec.Files, true, false))
+	if assert.Len(t, spec.Files, 3) {
+		// Inherits the defaults only
+		assert.Equal(t, "generic-local/1.0.0/", spec.Files[0].Target)
+		assert.Equal(t, "team=devops", spec.Files[0].Props)
+		assert.Equal(t, "true", spec.Files[0].Flat)
+		assert.Empty(t, spec.Files[0].Exclusions)
+
+		// The fragment overrides the defaults
+		assert.Equal(t, "generic-local/1.0.0/", spec.Files[1].Target)
+		assert.Equal(t, "release=true", spec.Files[1].Props)
+		assert.Equal(t, []string{"*.tmp"}, spec.Files[1].Exclusions)
+		assert.Empty(t, spec.Files[1].Fragments)
+
+		// The first fragment overrides the next ones, and the file fields override all
+		assert.Equal(t, "archives-local/", spec.Files[2].Target)
+		assert.Equal(t, "zip", spec.Files[2].Archive)
+		assert.Equal(t, "release=true", spec.Files[2].Props)
+		assert.Equal(t, "false", spec.Files[2].Flat)
+	}
+
+	// Environment variables override the default values
+	testsutils.SetEnvAndAssert(t, "TARGET_REPO", "env-local")
+	defer testsutils.UnSetEnvAndAssert(t, "TARGET_REPO")
+	spec, err = CreateSpecFromFile(filepath.Join("testdata", "templates.json"), map[string]string{"version": "1.0.0"})
+	assert.NoError(t, err)
+	assert.Equal(t, "env-local/1.0.0/", spec.Files[0].Target)
+}
+
+func TestCreateSpecFromFileUndefinedFragment(t *testing.T) {
+	_, err := CreateSpecFromFile(filepath.Join("testdata", "undefined-fragment.json"), nil)
+	assert.EqualError(t, err, "files[1]: the spec fragment 'missing' is not defined")
+}
+
+func TestExpandTemplateVars(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, "SPEC_TEST_ENV", "env")
+	defer testsutils.UnSetEnvAndAssert(t, "SPEC_TEST_ENV")
+	testsutils.SetEnvAndAssert(t, "SPEC_TEST_EMPTY_ENV", "")
+	defer testsutils.UnSetEnvAndAssert(t, "SPEC_TEST_EMPTY_ENV")
+	testsutils.SetEnvAndAssert(t, "SPEC_TEST_QUOTED_ENV", `a"b\c`)
+	defer testsutils.UnSetEnvAndAssert(t, "SPEC_TEST_QUOTED_ENV")
+
+	testCases := []struct {
+		content  string
+		expected string
+	}{
+		// Environment variables are used only by variables with a default value
+		{"${SPEC_TEST_ENV}", "${SPEC_TEST_ENV}"},
+		{"${SPEC_TEST_ENV:-default}", "env"},
+		{"${SPEC_TEST_EMPTY_ENV:-default}", "default"},
+		{"${SPEC_TEST_UNSET_ENV:-default}", "default"},
+		{"${SPEC_TEST_UNSET_ENV:-}", ""},
+		{"${SPEC_TEST_UNSET_ENV}", "${SPEC_TEST_UNSET_ENV}"},
+		{"${specVar}", "spec"},
+		{"${specVar:-default}", "spec"},
+		{"a/${specVar}/${SPEC_TEST_ENV:-b}/c", "a/spec/env/c"},
+		// Quotes and backslashes in the values don't break the spec
+		{"${SPEC_TEST_QUOTED_ENV:-default}", `a"b\c`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.content, func(t *testing.T) {
+			content, err := json.Marshal(map[string]any{"files": []any{map[string]any{"pattern": testCase.content, "recursive": json.Number("1")}}})
+			assert.NoError(t, err)
+			expanded, err := expandTemplateVars(content, map[string]string{"specVar": "spec"})
+			assert.NoError(t, err)
+			var spec struct {
+				Files []struct {
+					Pattern   string
+					Recursive json.Number
+				}
+			}
+			assert.NoError(t, json.Unmarshal(expanded, &spec))
+			assert.Equal(t, testCase.expected, spec.Files[0].Pattern)
+			assert.Equal(t, json.Number("1"), spec.Files[0].Recursive)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Matches ${NAME} and ${NAME:-default} variables.
+var templateVarRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_.\-]*)(:-([^}]*))?}`)
+
+// Expand the ${NAME} and ${NAME:-default} variables in the string values of the JSON spec content.
+// The values are expanded after decoding the content, so that expanded values containing quotes or backslashes can't break the spec.
+func expandTemplateVars(content []byte, specVars map[string]string) ([]byte, error) {
+	if !templateVarRegexp.Match(content) {
+		return content, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// Keep the numbers as is
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	expanded, err := json.Marshal(expandTemplateValue(decoded, specVars))
+	return expanded, errorutils.CheckError(err)
+}
+
+func expandTemplateValue(value any, specVars map[string]string) any {
+	switch actualValue := value.(type) {
+	case string:
+		return expandTemplateString(actualValue, specVars)
+	case []any:
+		for i := range actualValue {
+			actualValue[i] = expandTemplateValue(actualValue[i], specVars)
+		}
+	case map[string]any:
+		for key := range actualValue {
+			actualValue[key] = expandTemplateValue(actualValue[key], specVars)
+		}
+	}
+	return value
+}
+
+// Expand the variables in a string value of the spec.
+// The value of ${NAME} is taken from the spec vars. Literal ${NAME} text, which isn't a spec var, is left as is.
+// The value of ${NAME:-default} is taken from the spec vars, then from the environment variables, and then from its default value.
+func expandTemplateString(value string, specVars map[string]string) string {
+	return templateVarRegexp.ReplaceAllStringFunc(value, func(match string) string {
+		groups := templateVarRegexp.FindStringSubmatch(match)
+		name, hasDefault, defaultValue := groups[1], groups[2] != "", groups[3]
+		if specValue, ok := specVars[name]; ok {
+			return specValue
+		}
+		if !hasDefault {
+			return match
+		}
+		if envValue := os.Getenv(name); envValue != "" {
+			return envValue
+		}
+		log.Debug(fmt.Sprintf("Using the default value '%s' for the spec variable '%s'", defaultValue, name))
+		return defaultValue
+	})
+}
+
+// Apply the spec defaults and the named fragments on the spec files.
+// Each file inherits the fields of the fragments it references (in their order) and then the fields of the spec defaults.
+// A field which is set in a file is never overridden.
+func (spec *SpecFiles) resolveInheritance() error {
+	for name, fragment := range spec.Fragments {
+		if len(fragment.Fragments) > 0 {
+			return errorutils.CheckErrorf("the spec fragment '%s' cannot reference other fragments", name)
+		}
+	}
+	if spec.Defaults != nil && len(spec.Defaults.Fragments) > 0 {
+		return errorutils.CheckErrorf("the spec defaults cannot reference fragments")
+	}
+	for i := range spec.Files {
+		file := &spec.Files[i]
+		for _, fragmentName := range file.Fragments {
+			fragment, ok := spec.Fragments[fragmentName]
+			if !ok {
+				return errorutils.CheckErrorf("files[%d]: the spec fragment '%s' is not defined", i, fragmentName)
+			}
+			inheritFields(file, &fragment)
+		}
+		if spec.Defaults != nil {
+			inheritFields(file, spec.Defaults)
+		}
+		file.Fragments = nil
+	}
+	return nil
+}
+
+// Set the exported fields of the target file, which are not set, to the values of the source file.
+func inheritFields(target, source *File) {
+	targetValue := reflect.ValueOf(target).Elem()
+	sourceValue := reflect.ValueOf(source).Elem()
+	for i := 0; i < targetValue.NumField(); i++ {
+		field := targetValue.Field(i)
+		if !field.CanSet() || !field.IsZero() {
+			continue
+		}
+		sourceField := sourceValue.Field(i)
+		if sourceField.Kind() == reflect.Slice && !sourceField.IsNil() {
+			// Copy slices, so that the files don't share the same underlying array.
+			sourceField = reflect.AppendSlice(reflect.MakeSlice(sourceField.Type(), 0, sourceField.Len()), sourceField)
+		}
+		field.Set(sourceField)
+	}
+}
@@ -0,0 +1,95 @@
+package summary
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/commandsummary"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Records a custom section in the command summary, rendered from JSON data with a Go template.
+type RecordSectionCommand struct {
+	sectionName  string
+	template     string
+	templatePath string
+	data         string
+	dataPath     string
+	order        int
+}
+
+func NewRecordSectionCommand() *RecordSectionCommand {
+	return &RecordSectionCommand{}
+}
+
+func (rsc *RecordSectionCommand) SetSectionName(sectionName string) *RecordSectionCommand {
+	rsc.sectionName = sectionName
+	return rsc
+}
+
+func (rsc *RecordSectionCommand) SetTemplate(template string) *RecordSectionCommand {
+	rsc.template = template
+	return rsc
+}
+
+// Read the template from a file, instead of setting it with SetTemplate.
+func (rsc *RecordSectionCommand) SetTemplatePath(templatePath string) *RecordSectionCommand {
+	rsc.templatePath = templatePath
+	return rsc
+}
+
+// The data of the section as JSON.
+func (rsc *RecordSectionCommand) SetData(data string) *RecordSectionCommand {
+	rsc.data = data
+	return rsc
+}
+
+// Read the JSON data from a file, instead of setting it with SetData.
+func (rsc *RecordSectionCommand) SetDataPath(dataPath string) *RecordSectionCommand {
+	rsc.dataPath = dataPath
+	return rsc
+}
+
+func (rsc *RecordSectionCommand) SetOrder(order int) *RecordSectionCommand {
+	rsc.order = order
+	return rsc
+}
+
+func (rsc *RecordSectionCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (rsc *RecordSectionCommand) CommandName() string {
+	return "summary_record_section"
+}
+
+func (rsc *RecordSectionCommand) Run() error {
+	// Custom sections are recorded in the directory of the command summary, which is defined by the environment
+	if _, err := getOutputDir(""); err != nil {
+		return err
+	}
+	template, err := readValueOrFile(rsc.template, rsc.templatePath)
+	if err != nil {
+		return err
+	}
+	data, err := readValueOrFile(rsc.data, rsc.dataPath)
+	if err != nil {
+		return err
+	}
+	section := commandsummary.CustomSectionRecord{Name: rsc.sectionName, Template: template, Order: rsc.order}
+	if data != "" {
+		if err = json.Unmarshal([]byte(data), &section.Data); err != nil {
+			return errorutils.CheckErrorf("the data of the '%s' summary section isn't valid JSON: %s", rsc.sectionName, err.Error())
+		}
+	}
+	return commandsummary.RecordCustomSection(section)
+}
+
+func readValueOrFile(value, filePath string) (string, error) {
+	if filePath == "" {
+		return value, nil
+	}
+	content, err := os.ReadFile(filePath)
+	return string(content), errorutils.CheckError(err)
+}
@@ -0,0 +1,128 @@
+package summary
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/commandsummary"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Archives the command summary directory, so that it can be merged with the summaries of other jobs.
+type ArchiveCommand struct {
+	serverDetails *config.ServerDetails
+	outputDir     string
+	archivePath   string
+}
+
+func NewArchiveCommand() *ArchiveCommand {
+	return &ArchiveCommand{}
+}
+
+// The server details are optional. If provided, the archive records the platform URL and version, which are used to generate links in the merged summary.
+func (ac *ArchiveCommand) SetServerDetails(serverDetails *config.ServerDetails) *ArchiveCommand {
+	ac.serverDetails = serverDetails
+	return ac
+}
+
+// The directory of the command summary. Defaults to the JFROG_CLI_COMMAND_SUMMARY_OUTPUT_DIR environment variable.
+func (ac *ArchiveCommand) SetOutputDir(outputDir string) *ArchiveCommand {
+	ac.outputDir = outputDir
+	return ac
+}
+
+func (ac *ArchiveCommand) SetArchivePath(archivePath string) *ArchiveCommand {
+	ac.archivePath = archivePath
+	return ac
+}
+
+func (ac *ArchiveCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ac.serverDetails, nil
+}
+
+func (ac *ArchiveCommand) CommandName() string {
+	return "summary_archive"
+}
+
+func (ac *ArchiveCommand) Run() error {
+	outputDir, err := getOutputDir(ac.outputDir)
+	if err != nil {
+		return err
+	}
+	if ac.serverDetails != nil && ac.serverDetails.ArtifactoryUrl != "" {
+		if err = initMarkdownGenerationValues(ac.serverDetails); err != nil {
+			return err
+		}
+	}
+	return commandsummary.CreateSummaryArchive(outputDir, ac.archivePath)
+}
+
+// Merges the summary archives of several jobs into the command summary directory, and regenerates the summaries.
+type MergeCommand struct {
+	outputDir    string
+	archivePaths []string
+}
+
+func NewMergeCommand() *MergeCommand {
+	return &MergeCommand{}
+}
+
+// The directory of the merged command summary. Defaults to the JFROG_CLI_COMMAND_SUMMARY_OUTPUT_DIR environment variable.
+func (mc *MergeCommand) SetOutputDir(outputDir string) *MergeCommand {
+	mc.outputDir = outputDir
+	return mc
+}
+
+func (mc *MergeCommand) SetArchivePaths(archivePaths []string) *MergeCommand {
+	mc.archivePaths = archivePaths
+	return mc
+}
+
+func (mc *MergeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (mc *MergeCommand) CommandName() string {
+	return "summary_merge"
+}
+
+func (mc *MergeCommand) Run() error {
+	if len(mc.archivePaths) == 0 {
+		return errorutils.CheckErrorf("no command summary archives to merge were provided")
+	}
+	outputDir, err := getOutputDir(mc.outputDir)
+	if err != nil {
+		return err
+	}
+	return commandsummary.MergeSummaryArchives(outputDir, mc.archivePaths...)
+}
+
+func getOutputDir(outputDir string) (string, error) {
+	if outputDir != "" {
+		return outputDir, nil
+	}
+	if outputDir = os.Getenv(coreutils.SummaryOutputDirPathEnv); outputDir == "" {
+		return "", errorutils.CheckErrorf("the command summary directory is not defined. Please set the %s environment variable", coreutils.SummaryOutputDirPathEnv)
+	}
+	return outputDir, nil
+}
+
+func initMarkdownGenerationValues(serverDetails *config.ServerDetails) error {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	artifactoryVersion, err := servicesManager.GetVersion()
+	if err != nil {
+		return err
+	}
+	majorVersion, err := strconv.Atoi(strings.Split(artifactoryVersion, ".")[0])
+	if err != nil {
+		return errorutils.CheckErrorf("unexpected Artifactory version '%s'", artifactoryVersion)
+	}
+	return commandsummary.InitMarkdownGenerationValues(serverDetails.Url, majorVersion)
+}
@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/pipelines/manager"
+	"github.com/jfrog/jfrog-cli-core/v2/pipelines/status"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/pipelines/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// LogsCommand prints the console logs of the steps of a pipeline run
+type LogsCommand struct {
+	serverDetails *config.ServerDetails
+	branch        string
+	pipelineName  string
+	isMultiBranch bool
+	// Run number of the pipeline. If not set, the latest run is used
+	runNumber int
+	// Name of the step to print its logs. If not set, the logs of all the steps are printed
+	stepName string
+	// Follow the logs of the steps until the run ends
+	follow       bool
+	pollInterval time.Duration
+	// Max time to follow the logs
+	waitTimeout time.Duration
+}
+
+func NewLogsCommand() *LogsCommand {
+	return &LogsCommand{pollInterval: DefaultPollInterval, waitTimeout: DefaultWaitTimeout}
+}
+
+func (lc *LogsCommand) ServerDetails() (*config.ServerDetails, error) {
+	return lc.serverDetails, nil
+}
+
+func (lc *LogsCommand) SetServerDetails(serverDetails *config.ServerDetails) *LogsCommand {
+	lc.serverDetails = serverDetails
+	return lc
+}
+
+func (lc *LogsCommand) SetBranch(br string) *LogsCommand {
+	lc.branch = br
+	return lc
+}
+
+func (lc *LogsCommand) SetPipelineName(pl string) *LogsCommand {
+	lc.pipelineName = pl
+	return lc
+}
+
+func (lc *LogsCommand) SetMultiBranch(multiBranch bool) *LogsCommand {
+	lc.isMultiBranch = multiBranch
+	return lc
+}
+
+func (lc *LogsCommand) SetRunNumber(runNumber int) *LogsCommand {
+	lc.runNumber = runNumber
+	return lc
+}
+
+func (lc *LogsCommand) SetStepName(stepName string) *LogsCommand {
+	lc.stepName = stepName
+	return lc
+}
+
+func (lc *LogsCommand) SetFollow(follow bool) *LogsCommand {
+	lc.follow = follow
+	return lc
+}
+
+// SetPollInterval sets the interval between the polls of the logs in follow mode
+func (lc *LogsCommand) SetPollInterval(pollInterval time.Duration) *LogsCommand {
+	lc.pollInterval = pollInterval
+	return lc
+}
+
+// SetWaitTimeout sets the max time to follow the logs in follow mode
+func (lc *LogsCommand) SetWaitTimeout(waitTimeout time.Duration) *LogsCommand {
+	lc.waitTimeout = waitTimeout
+	return lc
+}
+
+func (lc *LogsCommand) CommandName() string {
+	return "pl_logs"
+}
+
+func (lc *LogsCommand) Run() error {
+	serviceManager, err := manager.CreateServiceManager(lc.serverDetails)
+	if err != nil {
+		return err
+	}
+	runsService, err := manager.CreateRunsService(lc.serverDetails, serviceManager)
+	if err != nil {
+		return err
+	}
+	pipeline, err := getPipelineRun(serviceManager, runsService, lc.branch, lc.pipelineName, lc.isMultiBranch, lc.runNumber)
+	if err != nil {
+		return err
+	}
+	if !lc.follow {
+		return lc.printRunLogs(context.Background(), runsService, pipeline.Run.ID, nil, true)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), lc.waitTimeout)
+	defer cancel()
+	return lc.followRunLogs(ctx, runsService, pipeline)
+}
+
+// followRunLogs prints the logs of the steps until the run ends, including the logs of the steps, which are created while following the run
+func (lc *LogsCommand) followRunLogs(ctx context.Context, runsService *manager.RunsService, pipeline *services.Pipelines) error {
+	printedSteps := make(map[int]bool)
+	for {
+		// Check whether the run ended before fetching its steps, so that the last steps are printed
+		run, err := runsService.GetRun(pipeline.Run.ID)
+		if err != nil {
+			return err
+		}
+		runEnded := status.IsRunEnded(status.GetPipelineStatus(run.StatusCode))
+		if err = lc.printRunLogs(ctx, runsService, run.ID, printedSteps, runEnded); err != nil || runEnded {
+			return err
+		}
+		if err = lc.waitForPoll(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// printRunLogs prints the logs of the steps of the run, which weren't printed yet.
+// printedSteps - The IDs of the steps, which logs were printed. Nil if the steps are printed once.
+// final - True if no more steps are created in the run. Otherwise, a missing step may be created later.
+func (lc *LogsCommand) printRunLogs(ctx context.Context, runsService *manager.RunsService, runId int, printedSteps map[int]bool, final bool) error {
+	steps, err := runsService.GetRunSteps(runId)
+	if err != nil {
+		return err
+	}
+	if lc.stepName != "" {
+		step := findStepByName(steps, lc.stepName)
+		if step == nil {
+			if !final {
+				return nil
+			}
+			var names []string
+			for i := range steps {
+				names = append(names, steps[i].Name)
+			}
+			return errorutils.CheckErrorf("step '%s' was not found in the run. The steps of the run are: %s", lc.stepName, strings.Join(names, ", "))
+		}
+		steps = []manager.Step{*step}
+	}
+	for i := range steps {
+		if printedSteps[steps[i].Id] {
+			continue
+		}
+		if err = lc.printStepLogs(ctx, runsService, &steps[i]); err != nil {
+			return err
+		}
+		if printedSteps != nil {
+			printedSteps[steps[i].Id] = true
+		}
+	}
+	return nil
+}
+
+// printStepLogs prints the console logs of the step. In follow mode, the new logs are printed until the step ends.
+func (lc *LogsCommand) printStepLogs(ctx context.Context, runsService *manager.RunsService, step *manager.Step) error {
+	log.Output(fmt.Sprintf("%14s %s", StepLabel, step.Name))
+	printedConsoles := make(map[string]bool)
+	for {
+		// Check whether the step ended before fetching the logs, so that the last logs are printed
+		ended := stepEnded(step)
+		consoles, err := runsService.GetStepConsoles(step.Id)
+		if err != nil {
+			return err
+		}
+		for i := range consoles {
+			if !printedConsoles[consoles[i].ConsoleId] {
+				printedConsoles[consoles[i].ConsoleId] = true
+				printConsole(&consoles[i])
+			}
+		}
+		if !lc.follow || ended {
+			stepStatus := status.GetPipelineStatus(step.StatusCode)
+			log.Output(status.GetStatusColorCode(stepStatus).Sprintf("%14s %s", StatusLabel, stepStatus))
+			return nil
+		}
+		if err = lc.waitForPoll(ctx); err != nil {
+			return err
+		}
+		if step, err = runsService.GetStep(step.Id); err != nil {
+			return err
+		}
+	}
+}
+
+// waitForPoll waits for the poll interval. Returns a CliError if the wait timeout is reached before.
+func (lc *LogsCommand) waitForPoll(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return coreutils.CliError{ExitCode: status.ExitCodeWaitTimeout, ErrorMsg: fmt.Sprintf("the run of pipeline '%s' didn't end within %s", lc.pipelineName, lc.waitTimeout)}
+	case <-time.After(lc.pollInterval):
+		return nil
+	}
+}
+
+func printConsole(console *manager.Console) {
+	if console.Message == "" {
+		return
+	}
+	if console.IsGroup() {
+		log.Output("==> " + console.Message)
+		return
+	}
+	log.Output(console.Message)
+}
+
+// findStepByName returns the step with the given name, or nil if it isn't found
+func findStepByName(steps []manager.Step, stepName string) *manager.Step {
+	for i := range steps {
+		if steps[i].Name == stepName {
+			return &steps[i]
+		}
+	}
+	return nil
+}
+
+// stepEnded returns true if the step is done and its status won't change anymore
+func stepEnded(step *manager.Step) bool {
+	return status.IsRunEnded(status.GetPipelineStatus(step.StatusCode))
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/jfrog/jfrog-cli-core/v2/pipelines/status"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogsCommand(t *testing.T) {
+	previousColorEnable := color.Disable()
+	defer func() {
+		color.Enable = previousColorEnable
+	}()
+	serverDetails, _ := createStepsTestServer(t)
+	output := captureOutput(t, func() {
+		assert.NoError(t, NewLogsCommand().SetServerDetails(serverDetails).SetPipelineName("build").Run())
+	})
+	assert.Equal(t, []string{"Step : compile", "==> Compiling", "compiled", "Status : success", "Step : test", "==> Testing", "test 1 failed", "test 2 passed", "Status : failure"},
+		trimLines(output))
+}
+
+func TestLogsCommandFollow(t *testing.T) {
+	previousColorEnable := color.Disable()
+	defer func() {
+		color.Enable = previousColorEnable
+	}()
+	serverDetails, state := createStepsTestServer(t)
+	// The 'test' step is running, and ends after the second poll
+	state.setRunningStep(12, 2)
+	output := captureOutput(t, func() {
+		assert.NoError(t, NewLogsCommand().SetServerDetails(serverDetails).SetPipelineName("build").SetStepName("test").SetFollow(true).SetPollInterval(time.Millisecond).Run())
+	})
+	assert.Equal(t, []string{"Step : test", "==> Testing", "test 1 failed", "test 2 passed", "Status : failure"}, trimLines(output))
+}
+
+func TestLogsCommandFollowCreatedStep(t *testing.T) {
+	previousColorEnable := color.Disable()
+	defer func() {
+		color.Enable = previousColorEnable
+	}()
+	serverDetails, state := createStepsTestServer(t)
+	// The 'test' step is created after the second poll of the run
+	state.setPendingStep(12, 2)
+	output := captureOutput(t, func() {
+		assert.NoError(t, NewLogsCommand().SetServerDetails(serverDetails).SetPipelineName("build").SetFollow(true).SetPollInterval(time.Millisecond).Run())
+	})
+	assert.Equal(t, []string{"Step : compile", "==> Compiling", "compiled", "Status : success", "Step : test", "==> Testing", "test 1 failed", "test 2 passed", "Status : failure"},
+		trimLines(output))
+
+	// A step, which isn't found after the run ended
+	state.setPendingStep(0, 0)
+	err := NewLogsCommand().SetServerDetails(serverDetails).SetPipelineName("build").SetStepName("deploy").SetFollow(true).SetPollInterval(time.Millisecond).Run()
+	assert.ErrorContains(t, err, "step 'deploy' was not found in the run")
+}
+
+func TestLogsCommandFollowTimeout(t *testing.T) {
+	serverDetails, state := createStepsTestServer(t)
+	// The 'test' step doesn't end
+	state.setRunningStep(12, math.MaxInt)
+	err := NewLogsCommand().SetServerDetails(serverDetails).SetPipelineName("build").SetStepName("test").SetFollow(true).
+		SetPollInterval(time.Millisecond).SetWaitTimeout(20 * time.Millisecond).Run()
+	assertExitCode(t, status.ExitCodeWaitTimeout, err)
+}
+
+func TestLogsCommandStepNotFound(t *testing.T) {
+	serverDetails, _ := createStepsTestServer(t)
+	err := NewLogsCommand().SetServerDetails(serverDetails).SetPipelineName("build").SetStepName("deploy").Run()
+	assert.ErrorContains(t, err, "step 'deploy' was not found in the run. The steps of the run are: compile, test")
+}
+
+// Returns the trimmed lines of the output. The output must be printed without colors.
+func trimLines(output string) []string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return lines
+}
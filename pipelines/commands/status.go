@@ -2,16 +2,21 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/gookit/color"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
 	"github.com/jfrog/jfrog-cli-core/v2/pipelines/manager"
 	"github.com/jfrog/jfrog-cli-core/v2/pipelines/status"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/pipelines"
 	"github.com/jfrog/jfrog-client-go/pipelines/services"
 	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"golang.org/x/exp/slices"
+	"strconv"
 	"time"
 )
 
@@ -25,6 +30,45 @@ type StatusCommand struct {
 	// Notify used for determining for continuous monitoring of status and notifying
 	notify        bool
 	isMultiBranch bool
+	// Output format of the statuses. If not set, the statuses are printed as colored text
+	outputFormat format.OutputFormat
+	// Statuses to apply filter on pipeline statuses. If empty, all the statuses are printed
+	statuses []status.PipelineStatus
+	// Time range to apply filter on the creation time of the latest runs. Zero values aren't applied
+	createdAfter  time.Time
+	createdBefore time.Time
+}
+
+// PipelineRunStatus is the status of the latest run of a pipeline, as printed in json format
+type PipelineRunStatus struct {
+	Pipeline        string                `json:"pipeline"`
+	Branch          string                `json:"branch"`
+	RunNumber       int                   `json:"runNumber"`
+	Status          status.PipelineStatus `json:"status"`
+	CreatedAt       time.Time             `json:"createdAt"`
+	DurationSeconds int                   `json:"durationSeconds"`
+	Steps           []StepStatus          `json:"steps"`
+}
+
+type StepStatus struct {
+	Name            string                `json:"name"`
+	Status          status.PipelineStatus `json:"status"`
+	DurationSeconds int                   `json:"durationSeconds"`
+}
+
+type pipelineStatusTableRow struct {
+	Pipeline string               `col-name:"Pipeline"`
+	Branch   string               `col-name:"Branch"`
+	Run      string               `col-name:"Run"`
+	Status   string               `col-name:"Status"`
+	Duration string               `col-name:"Duration"`
+	Steps    []stepStatusTableRow `embed-table:"true"`
+}
+
+type stepStatusTableRow struct {
+	Name     string `col-name:"Step"`
+	Status   string `col-name:"Step Status"`
+	Duration string `col-name:"Step Duration"`
 }
 
 const (
@@ -74,7 +118,32 @@ func (sc *StatusCommand) SetMultiBranch(multiBranch bool) *StatusCommand {
 	return sc
 }
 
+func (sc *StatusCommand) SetOutputFormat(outputFormat format.OutputFormat) *StatusCommand {
+	sc.outputFormat = outputFormat
+	return sc
+}
+
+func (sc *StatusCommand) SetStatuses(statuses []status.PipelineStatus) *StatusCommand {
+	sc.statuses = statuses
+	return sc
+}
+
+func (sc *StatusCommand) SetCreatedAfter(createdAfter time.Time) *StatusCommand {
+	sc.createdAfter = createdAfter
+	return sc
+}
+
+func (sc *StatusCommand) SetCreatedBefore(createdBefore time.Time) *StatusCommand {
+	sc.createdBefore = createdBefore
+	return sc
+}
+
 func (sc *StatusCommand) Run() error {
+	switch sc.outputFormat {
+	case "", format.Table, format.Json:
+	default:
+		return errorutils.CheckErrorf("the pipelines status command supports only the %s and %s output formats", format.Table, format.Json)
+	}
 	// Create service manager to fetch run status
 	serviceManager, err := manager.CreateServiceManager(sc.serverDetails)
 	if err != nil {
@@ -86,27 +155,150 @@ func (sc *StatusCommand) Run() error {
 	if err != nil {
 		return err
 	}
-	var res string
+	var pipes []services.Pipelines
 	for i := range matchingPipes.Pipelines {
 		pipe := matchingPipes.Pipelines[i]
 		// Eliminate pipelines which have not been run
 		if pipe.LatestRunID != 0 {
 			// When notification option is selected use this flow to notify
 			if sc.pipelineName != "" && sc.notify {
-				err := monitorStatusAndNotify(context.Background(), serviceManager, sc.branch, sc.pipelineName, sc.isMultiBranch)
+				err := monitorStatusAndNotify(context.Background(), serviceManager, sc.branch, sc.pipelineName, sc.isMultiBranch, sc.statusChangePrinter(serviceManager))
 				if err != nil {
 					return err
 				}
-			} else {
-				respStatus, colorCode, duration := getPipelineStatusAndColorCode(&pipe)
-				res += colorCode.Sprintf("\n%s %s\n%14s %s\n%14s %d \n%14s %s \n%14s %s\n", PipelineName,
-					pipe.Name, Branch, pipe.PipelineSourceBranch, Run, pipe.Run.RunNumber, Duration,
-					duration, StatusLabel, string(respStatus))
+			} else if sc.matchesFilters(&pipe) {
+				pipes = append(pipes, pipe)
+			}
+		}
+	}
+	if sc.pipelineName != "" && sc.notify {
+		return nil
+	}
+	return sc.printStatuses(serviceManager, pipes)
+}
+
+// matchesFilters returns true if the latest run of the pipeline matches the statuses and the time range filters
+func (sc *StatusCommand) matchesFilters(pipeline *services.Pipelines) bool {
+	if len(sc.statuses) > 0 && !slices.Contains(sc.statuses, status.GetPipelineStatus(pipeline.Run.StatusCode)) {
+		return false
+	}
+	if !sc.createdAfter.IsZero() && pipeline.Run.CreatedAt.Before(sc.createdAfter) {
+		return false
+	}
+	return sc.createdBefore.IsZero() || pipeline.Run.CreatedAt.Before(sc.createdBefore)
+}
+
+func (sc *StatusCommand) printStatuses(pipelinesMgr *pipelines.PipelinesServicesManager, pipes []services.Pipelines) error {
+	if sc.outputFormat == "" {
+		var res string
+		for i := range pipes {
+			res += getColoredPipelineStatus(&pipes[i])
+		}
+		log.Output(res)
+		return nil
+	}
+	runStatuses, err := sc.getRunStatuses(pipelinesMgr, pipes)
+	if err != nil {
+		return err
+	}
+	if sc.outputFormat == format.Json {
+		content, err := json.MarshalIndent(runStatuses, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	}
+	return printRunStatusesTable(runStatuses)
+}
+
+// statusChangePrinter returns the function, which prints the status of the pipeline when it changes.
+// In json format, each status is printed as a single line.
+func (sc *StatusCommand) statusChangePrinter(pipelinesMgr *pipelines.PipelinesServicesManager) func(pipeline *services.Pipelines) error {
+	return func(pipeline *services.Pipelines) error {
+		if sc.outputFormat == "" {
+			log.Output(getColoredPipelineStatus(pipeline))
+			return nil
+		}
+		runStatuses, err := sc.getRunStatuses(pipelinesMgr, []services.Pipelines{*pipeline})
+		if err != nil {
+			return err
+		}
+		if sc.outputFormat == format.Json {
+			content, err := json.Marshal(runStatuses[0])
+			if err != nil {
+				return errorutils.CheckError(err)
 			}
+			log.Output(string(content))
+			return nil
+		}
+		return printRunStatusesTable(runStatuses)
+	}
+}
+
+// getRunStatuses returns the statuses of the latest runs of the pipelines, including the statuses of their steps
+func (sc *StatusCommand) getRunStatuses(pipelinesMgr *pipelines.PipelinesServicesManager, pipes []services.Pipelines) ([]PipelineRunStatus, error) {
+	runStatuses := make([]PipelineRunStatus, 0, len(pipes))
+	if len(pipes) == 0 {
+		return runStatuses, nil
+	}
+	runsService, err := manager.CreateRunsService(sc.serverDetails, pipelinesMgr)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pipes {
+		steps, err := runsService.GetRunSteps(pipes[i].LatestRunID)
+		if err != nil {
+			return nil, err
+		}
+		runStatuses = append(runStatuses, createRunStatus(&pipes[i], steps))
+	}
+	return runStatuses, nil
+}
+
+func createRunStatus(pipeline *services.Pipelines, steps []manager.Step) PipelineRunStatus {
+	runStatus := PipelineRunStatus{
+		Pipeline:        pipeline.Name,
+		Branch:          pipeline.PipelineSourceBranch,
+		RunNumber:       pipeline.Run.RunNumber,
+		Status:          status.GetPipelineStatus(pipeline.Run.StatusCode),
+		CreatedAt:       pipeline.Run.CreatedAt,
+		DurationSeconds: getRunDurationSeconds(&pipeline.Run),
+		Steps:           make([]StepStatus, 0, len(steps)),
+	}
+	for i := range steps {
+		runStatus.Steps = append(runStatus.Steps, StepStatus{
+			Name:            steps[i].Name,
+			Status:          status.GetPipelineStatus(steps[i].StatusCode),
+			DurationSeconds: steps[i].DurationSeconds(),
+		})
+	}
+	return runStatus
+}
+
+func printRunStatusesTable(runStatuses []PipelineRunStatus) error {
+	rows := make([]pipelineStatusTableRow, 0, len(runStatuses))
+	for _, runStatus := range runStatuses {
+		row := pipelineStatusTableRow{
+			Pipeline: runStatus.Pipeline,
+			Branch:   runStatus.Branch,
+			Run:      strconv.Itoa(runStatus.RunNumber),
+			Status:   string(runStatus.Status),
+			Duration: convertSecToDay(runStatus.DurationSeconds),
 		}
+		for _, step := range runStatus.Steps {
+			row.Steps = append(row.Steps, stepStatusTableRow{Name: step.Name, Status: string(step.Status), Duration: convertSecToDay(step.DurationSeconds)})
+		}
+		rows = append(rows, row)
 	}
-	log.Output(res)
-	return nil
+	return coreutils.PrintTable(rows, "Pipelines Status", "No pipeline runs were found", false)
+}
+
+func getColoredPipelineStatus(pipeline *services.Pipelines) string {
+	respStatus, colorCode, duration := getPipelineStatusAndColorCode(pipeline)
+	return colorCode.Sprintf("\n%s %s\n%14s %s\n%14s %d \n%14s %s \n%14s %s\n", PipelineName,
+		pipeline.Name, Branch, pipeline.PipelineSourceBranch, Run, pipeline.Run.RunNumber, Duration,
+		duration, StatusLabel, string(respStatus))
 }
 
 // getPipelineStatusAndColorCode from received pipeline statusCode
@@ -116,13 +308,31 @@ func (sc *StatusCommand) Run() error {
 func getPipelineStatusAndColorCode(pipeline *services.Pipelines) (pipelineStatus status.PipelineStatus, colorCode color.Color, duration string) {
 	pipelineStatus = status.GetPipelineStatus(pipeline.Run.StatusCode)
 	colorCode = status.GetStatusColorCode(pipelineStatus)
-	durationSeconds := pipeline.Run.DurationSeconds
+	return pipelineStatus, colorCode, convertSecToDay(getRunDurationSeconds(&pipeline.Run))
+}
+
+func getRunDurationSeconds(run *services.Run) int {
+	durationSeconds := run.DurationSeconds
 	if durationSeconds == 0 {
 		// Calculate the duration time by differentiating created time from the current time
-		durationSeconds = int(time.Now().Unix() - pipeline.Run.CreatedAt.Unix())
+		durationSeconds = int(time.Now().Unix() - run.CreatedAt.Unix())
 	}
+	return durationSeconds
+}
 
-	return pipelineStatus, colorCode, convertSecToDay(durationSeconds)
+// ParseRunTime parses a time range filter value, which is either a date e.g. 2024-01-31,
+// a timestamp in RFC3339 format e.g. 2024-01-31T10:00:00Z, or a duration before now e.g. 24h
+func ParseRunTime(value string) (time.Time, error) {
+	if duration, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+	if parsedTime, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsedTime, nil
+	}
+	if parsedTime, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return parsedTime, nil
+	}
+	return time.Time{}, errorutils.CheckErrorf("invalid time '%s'. Expected a date (2006-01-02), an RFC3339 timestamp (2006-01-02T15:04:05Z07:00) or a duration before now (24h)", value)
 }
 
 // ConvertSecToDay converts seconds passed as integer to Days, Hours, Minutes, Seconds
@@ -145,7 +355,7 @@ func convertSecToDay(sec int) string {
 
 // monitorStatusAndNotify monitors for status change and
 // sends notification if there is a change identified in the pipeline run status
-func monitorStatusAndNotify(ctx context.Context, pipelinesMgr *pipelines.PipelinesServicesManager, branch string, pipName string, isMultiBranch bool) error {
+func monitorStatusAndNotify(ctx context.Context, pipelinesMgr *pipelines.PipelinesServicesManager, branch string, pipName string, isMultiBranch bool, printStatus func(pipeline *services.Pipelines) error) error {
 	var previousStatus string
 
 	retryExecutor := utils.RetryExecutor{
@@ -159,12 +369,11 @@ func monitorStatusAndNotify(ctx context.Context, pipelinesMgr *pipelines.Pipelin
 				return false, err
 			}
 			pipeline := pipelineStatus.Pipelines[0]
-			currentStatus, colorCode, duration := getPipelineStatusAndColorCode(&pipeline)
+			currentStatus := status.GetPipelineStatus(pipeline.Run.StatusCode)
 			if pipelineStatusChanged(string(currentStatus), previousStatus) {
-				changedPipelineStatus := colorCode.Sprintf("\n%s %s\n%14s %s\n%14s %d \n%14s %s \n%14s %s\n", PipelineName,
-					pipeline.Name, Branch, pipeline.PipelineSourceBranch, Run, pipeline.Run.RunNumber, Duration,
-					duration, StatusLabel, string(currentStatus))
-				log.Output(changedPipelineStatus)
+				if err = printStatus(&pipeline); err != nil {
+					return false, err
+				}
 				if pipelineRunEnded(string(currentStatus)) {
 					return false, nil
 				}
@@ -186,9 +395,8 @@ func pipelineStatusChanged(currentStatus, previousState string) bool {
 }
 
 // pipelineRunEnded if pipeline status is one of
-// CANCELLED, FAILED, SUCCESS, ERROR, TIMEOUT pipeline run
-// life is considered to be done.
+// the statuses a pipeline run ends with, such as SUCCESS, FAILURE or CANCELLED,
+// the pipeline run life is considered to be done.
 func pipelineRunEnded(pipStatus string) bool {
-	pipRunEndLife := []string{string(status.SUCCESS), string(status.FAILURE), string(status.ERROR), string(status.CANCELLED), string(status.TIMEOUT)}
-	return slices.Contains(pipRunEndLife, pipStatus)
+	return status.IsRunEnded(status.PipelineStatus(pipStatus))
 }
@@ -1,9 +1,18 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
 	"github.com/gookit/color"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/pipelines/manager"
+	"github.com/jfrog/jfrog-cli-core/v2/pipelines/status"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/pipelines/services"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -126,3 +135,119 @@ func TestConvertSecToDay(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusCommandFilters(t *testing.T) {
+	now := time.Now()
+	pipeline := &services.Pipelines{Run: services.Run{StatusCode: 4003, CreatedAt: now.Add(-2 * time.Hour)}}
+	testCases := []struct {
+		name          string
+		statuses      []status.PipelineStatus
+		createdAfter  time.Time
+		createdBefore time.Time
+		want          bool
+	}{
+		{"no filters", nil, time.Time{}, time.Time{}, true},
+		{"matching status", []status.PipelineStatus{status.SUCCESS, status.FAILURE}, time.Time{}, time.Time{}, true},
+		{"other status", []status.PipelineStatus{status.SUCCESS}, time.Time{}, time.Time{}, false},
+		{"created after", nil, now.Add(-3 * time.Hour), time.Time{}, true},
+		{"created before the range", nil, now.Add(-time.Hour), time.Time{}, false},
+		{"created in the range", nil, now.Add(-3 * time.Hour), now.Add(-time.Hour), true},
+		{"created after the range", nil, time.Time{}, now.Add(-3 * time.Hour), false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			sc := NewStatusCommand().SetStatuses(testCase.statuses).SetCreatedAfter(testCase.createdAfter).SetCreatedBefore(testCase.createdBefore)
+			assert.Equal(t, testCase.want, sc.matchesFilters(pipeline))
+		})
+	}
+}
+
+func TestParseRunTime(t *testing.T) {
+	parsedTime, err := ParseRunTime("2024-01-31T10:00:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC), parsedTime.UTC())
+
+	parsedTime, err = ParseRunTime("2024-01-31")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.Local), parsedTime)
+
+	parsedTime, err = ParseRunTime("24h")
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), parsedTime, time.Minute)
+
+	_, err = ParseRunTime("yesterday")
+	assert.ErrorContains(t, err, "invalid time 'yesterday'")
+}
+
+func TestStatusCommandJsonOutput(t *testing.T) {
+	createdAt := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	testPipelines := []services.Pipelines{
+		{Name: "build", PipelineSourceBranch: "main", LatestRunID: 11, Run: services.Run{RunNumber: 3, StatusCode: 4003, DurationSeconds: 65, CreatedAt: createdAt}},
+		{Name: "release", PipelineSourceBranch: "main", LatestRunID: 12, Run: services.Run{RunNumber: 7, StatusCode: 4002, DurationSeconds: 30, CreatedAt: createdAt}},
+		{Name: "never-run", PipelineSourceBranch: "main"},
+	}
+	testSteps := map[string][]manager.Step{
+		"11": {
+			{Id: 2, Name: "test", StatusCode: 4003, StartedAt: createdAt.Add(20 * time.Second), EndedAt: createdAt.Add(65 * time.Second)},
+			{Id: 1, Name: "compile", StatusCode: 4002, StartedAt: createdAt, EndedAt: createdAt.Add(20 * time.Second)},
+		},
+	}
+	serverDetails := createPipelinesTestServer(t, testPipelines, testSteps)
+
+	output := captureOutput(t, func() {
+		assert.NoError(t, NewStatusCommand().SetServerDetails(serverDetails).SetOutputFormat(format.Json).SetStatuses([]status.PipelineStatus{status.FAILURE}).Run())
+	})
+	var runStatuses []PipelineRunStatus
+	assert.NoError(t, json.Unmarshal([]byte(output), &runStatuses))
+	assert.Equal(t, []PipelineRunStatus{{
+		Pipeline:        "build",
+		Branch:          "main",
+		RunNumber:       3,
+		Status:          status.FAILURE,
+		CreatedAt:       createdAt,
+		DurationSeconds: 65,
+		Steps: []StepStatus{
+			{Name: "compile", Status: status.SUCCESS, DurationSeconds: 20},
+			{Name: "test", Status: status.FAILURE, DurationSeconds: 45},
+		},
+	}}, runStatuses)
+}
+
+func TestStatusCommandUnsupportedFormat(t *testing.T) {
+	assert.ErrorContains(t, NewStatusCommand().SetOutputFormat(format.Sarif).Run(), "supports only the table and json output formats")
+}
+
+// Create a pipelines server, which returns the pipelines and the steps of their runs by the run ID.
+func createPipelinesTestServer(t *testing.T, pipelines []services.Pipelines, steps map[string][]manager.Step) *config.ServerDetails {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var content []byte
+		var err error
+		switch r.URL.Path {
+		case "/api/v1/search/pipelines/":
+			content, err = json.Marshal(services.PipelineRunStatusResponse{TotalCount: len(pipelines), Pipelines: pipelines})
+		case "/api/v1/steps":
+			content, err = json.Marshal(append([]manager.Step{}, steps[r.URL.Query().Get("runIds")]...))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(content)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+	return &config.ServerDetails{PipelinesUrl: server.URL + "/", AccessToken: "token"}
+}
+
+// Capture the content printed with log.Output by the action.
+func captureOutput(t *testing.T, action func()) string {
+	previousLog := log.Logger
+	newLog := log.NewLogger(log.INFO, nil)
+	buffer := &bytes.Buffer{}
+	newLog.SetOutputWriter(buffer)
+	log.SetLogger(newLog)
+	defer log.SetLogger(previousLog)
+	action()
+	return buffer.String()
+}
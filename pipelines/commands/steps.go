@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/pipelines/manager"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/pipelines"
+	"github.com/jfrog/jfrog-client-go/pipelines/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// StepsCommand lists the steps of a pipeline run, with their statuses and durations
+type StepsCommand struct {
+	serverDetails *config.ServerDetails
+	branch        string
+	pipelineName  string
+	isMultiBranch bool
+	// Run number of the pipeline. If not set, the latest run is used
+	runNumber    int
+	outputFormat format.OutputFormat
+}
+
+type stepTableRow struct {
+	Name     string `col-name:"Step"`
+	Status   string `col-name:"Status"`
+	Duration string `col-name:"Duration"`
+}
+
+func NewStepsCommand() *StepsCommand {
+	return &StepsCommand{outputFormat: format.Table}
+}
+
+func (sc *StepsCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+func (sc *StepsCommand) SetServerDetails(serverDetails *config.ServerDetails) *StepsCommand {
+	sc.serverDetails = serverDetails
+	return sc
+}
+
+func (sc *StepsCommand) SetBranch(br string) *StepsCommand {
+	sc.branch = br
+	return sc
+}
+
+func (sc *StepsCommand) SetPipelineName(pl string) *StepsCommand {
+	sc.pipelineName = pl
+	return sc
+}
+
+func (sc *StepsCommand) SetMultiBranch(multiBranch bool) *StepsCommand {
+	sc.isMultiBranch = multiBranch
+	return sc
+}
+
+func (sc *StepsCommand) SetRunNumber(runNumber int) *StepsCommand {
+	sc.runNumber = runNumber
+	return sc
+}
+
+func (sc *StepsCommand) SetOutputFormat(outputFormat format.OutputFormat) *StepsCommand {
+	sc.outputFormat = outputFormat
+	return sc
+}
+
+func (sc *StepsCommand) CommandName() string {
+	return "pl_steps"
+}
+
+func (sc *StepsCommand) Run() error {
+	if sc.outputFormat != format.Table && sc.outputFormat != format.Json {
+		return errorutils.CheckErrorf("the pipelines steps command supports only the %s and %s output formats", format.Table, format.Json)
+	}
+	serviceManager, err := manager.CreateServiceManager(sc.serverDetails)
+	if err != nil {
+		return err
+	}
+	runsService, err := manager.CreateRunsService(sc.serverDetails, serviceManager)
+	if err != nil {
+		return err
+	}
+	pipeline, err := getPipelineRun(serviceManager, runsService, sc.branch, sc.pipelineName, sc.isMultiBranch, sc.runNumber)
+	if err != nil {
+		return err
+	}
+	steps, err := runsService.GetRunSteps(pipeline.Run.ID)
+	if err != nil {
+		return err
+	}
+	runStatus := createRunStatus(pipeline, steps)
+	if sc.outputFormat == format.Json {
+		content, err := json.MarshalIndent(runStatus, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	}
+	rows := make([]stepTableRow, 0, len(runStatus.Steps))
+	for _, step := range runStatus.Steps {
+		rows = append(rows, stepTableRow{Name: step.Name, Status: string(step.Status), Duration: convertSecToDay(step.DurationSeconds)})
+	}
+	title := fmt.Sprintf("Steps of run %d of pipeline '%s' (%s)", runStatus.RunNumber, runStatus.Pipeline, runStatus.Status)
+	return coreutils.PrintTable(rows, title, "No steps were found", false)
+}
+
+// getPipelineRun returns the pipeline with the run by its number, or with its latest run if the run number isn't set
+func getPipelineRun(pipelinesMgr *pipelines.PipelinesServicesManager, runsService *manager.RunsService, branch, pipelineName string, isMultiBranch bool, runNumber int) (*services.Pipelines, error) {
+	pipeline, err := getPipeline(pipelinesMgr, branch, pipelineName, isMultiBranch)
+	if err != nil {
+		return nil, err
+	}
+	if pipeline == nil {
+		return nil, errorutils.CheckErrorf("pipeline '%s' was not found", pipelineName)
+	}
+	if runNumber == 0 {
+		if pipeline.LatestRunID == 0 {
+			return nil, errorutils.CheckErrorf("pipeline '%s' has not been run", pipelineName)
+		}
+		run, err := runsService.GetRun(pipeline.LatestRunID)
+		if err != nil {
+			return nil, err
+		}
+		pipeline.Run = *run
+		return pipeline, nil
+	}
+	run, err := runsService.GetRunByNumber(pipeline.ID, runNumber)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, errorutils.CheckErrorf("run %d of pipeline '%s' was not found", runNumber, pipelineName)
+	}
+	pipeline.Run = *run
+	return pipeline, nil
+}
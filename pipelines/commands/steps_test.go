@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/pipelines/manager"
+	"github.com/jfrog/jfrog-cli-core/v2/pipelines/status"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/pipelines/services"
+	"github.com/stretchr/testify/assert"
+)
+
+var stepsTestStartTime = time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+
+func TestStepsCommand(t *testing.T) {
+	serverDetails, _ := createStepsTestServer(t)
+	testCases := []struct {
+		name              string
+		runNumber         int
+		expectedRunNumber int
+		expectedStatus    status.PipelineStatus
+		expectedSteps     []StepStatus
+	}{
+		{"latest run", 0, 2, status.FAILURE, []StepStatus{{Name: "compile", Status: status.SUCCESS, DurationSeconds: 20}, {Name: "test", Status: status.FAILURE, DurationSeconds: 45}}},
+		{"run by number", 1, 1, status.SUCCESS, []StepStatus{{Name: "compile", Status: status.SUCCESS, DurationSeconds: 10}}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output := captureOutput(t, func() {
+				assert.NoError(t, NewStepsCommand().SetServerDetails(serverDetails).SetPipelineName("build").SetRunNumber(testCase.runNumber).SetOutputFormat(format.Json).Run())
+			})
+			var runStatus PipelineRunStatus
+			assert.NoError(t, json.Unmarshal([]byte(output), &runStatus))
+			assert.Equal(t, testCase.expectedRunNumber, runStatus.RunNumber)
+			assert.Equal(t, testCase.expectedStatus, runStatus.Status)
+			assert.Equal(t, testCase.expectedSteps, runStatus.Steps)
+		})
+	}
+}
+
+func TestStepsCommandRunNotFound(t *testing.T) {
+	serverDetails, _ := createStepsTestServer(t)
+	assert.ErrorContains(t, NewStepsCommand().SetServerDetails(serverDetails).SetPipelineName("build").SetRunNumber(3).Run(), "run 3 of pipeline 'build' was not found")
+	assert.ErrorContains(t, NewStepsCommand().SetServerDetails(serverDetails).SetPipelineName("deploy").Run(), "pipeline 'deploy' was not found")
+}
+
+// The state of the steps test server
+type stepsTestServerState struct {
+	mutex sync.Mutex
+	// ID of the step, which is running until it's polled the given number of times. Its logs are added one per poll.
+	runningStepId int
+	runningPolls  int
+	polls         int
+	// ID of the step, which is created after the run is polled the given number of times. The run is processing until then.
+	pendingStepId   int
+	pendingRunPolls int
+	runPolls        int
+}
+
+func (s *stepsTestServerState) setRunningStep(stepId, polls int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.runningStepId, s.runningPolls = stepId, polls
+}
+
+func (s *stepsTestServerState) setPendingStep(stepId, runPolls int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pendingStepId, s.pendingRunPolls = stepId, runPolls
+}
+
+// Create a pipelines server, in which the 'build' pipeline has two runs. The latest run 2 failed in its 'test' step.
+func createStepsTestServer(t *testing.T) (*config.ServerDetails, *stepsTestServerState) {
+	state := &stepsTestServerState{}
+	runs := map[int]services.Run{
+		1: {ID: 1, RunNumber: 1, StatusCode: 4002, CreatedAt: stepsTestStartTime, DurationSeconds: 10},
+		2: {ID: 2, RunNumber: 2, StatusCode: 4003, CreatedAt: stepsTestStartTime, DurationSeconds: 65},
+	}
+	steps := map[int][]manager.Step{
+		1: {{Id: 10, Name: "compile", RunId: 1, StatusCode: 4002, StartedAt: stepsTestStartTime, EndedAt: stepsTestStartTime.Add(10 * time.Second)}},
+		2: {
+			{Id: 11, Name: "compile", RunId: 2, StatusCode: 4002, StartedAt: stepsTestStartTime, EndedAt: stepsTestStartTime.Add(20 * time.Second)},
+			{Id: 12, Name: "test", RunId: 2, StatusCode: 4003, StartedAt: stepsTestStartTime.Add(20 * time.Second), EndedAt: stepsTestStartTime.Add(65 * time.Second)},
+		},
+	}
+	consoles := map[string][]manager.Console{
+		"11": {{ConsoleId: "c1", Type: "grp", Message: "Compiling", Timestamp: 1}, {ConsoleId: "c2", ParentConsoleId: "c1", Type: "msg", Message: "compiled", Timestamp: 2}},
+		"12": {{ConsoleId: "t1", Type: "grp", Message: "Testing", Timestamp: 3}, {ConsoleId: "t2", ParentConsoleId: "t1", Type: "msg", Message: "test 1 failed", Timestamp: 4}, {ConsoleId: "t3", ParentConsoleId: "t1", Type: "msg", Message: "test 2 passed", Timestamp: 5}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state.mutex.Lock()
+		defer state.mutex.Unlock()
+		var content any
+		switch path := r.URL.Path; {
+		case path == "/api/v1/search/pipelines/":
+			content = services.PipelineRunStatusResponse{TotalCount: 1, Pipelines: []services.Pipelines{{ID: 5, Name: "build", PipelineSourceBranch: "main", LatestRunID: 2, Run: runs[2]}}}
+		case path == "/api/v1/runs/2":
+			run := runs[2]
+			if state.runPolls < state.pendingRunPolls {
+				run.StatusCode = 4001
+			}
+			state.runPolls++
+			content = run
+		case path == "/api/v1/runs":
+			assert.Equal(t, "5", r.URL.Query().Get("pipelineIds"))
+			var matchingRuns []services.Run
+			for _, run := range runs {
+				if r.URL.Query().Get("runNumbers") == strconv.Itoa(run.RunNumber) {
+					matchingRuns = append(matchingRuns, run)
+				}
+			}
+			content = append([]services.Run{}, matchingRuns...)
+		case path == "/api/v1/steps":
+			runId, err := strconv.Atoi(r.URL.Query().Get("runIds"))
+			assert.NoError(t, err)
+			runSteps := steps[runId]
+			content = state.applyRunningStep(runSteps)
+		case path == "/api/v1/steps/12":
+			state.polls++
+			content = state.applyRunningStep(steps[2])[1]
+		case strings.HasSuffix(path, "/consoles"):
+			stepConsoles := consoles[strings.TrimSuffix(strings.TrimPrefix(path, "/api/v1/steps/"), "/consoles")]
+			if state.runningStepId != 0 && state.polls < state.runningPolls {
+				// The logs of the running step are added one per poll
+				stepConsoles = stepConsoles[:min(state.polls+2, len(stepConsoles))]
+			}
+			content = stepConsoles
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := json.Marshal(content)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(body)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+	return &config.ServerDetails{PipelinesUrl: server.URL + "/", AccessToken: "token"}, state
+}
+
+// Returns the steps, with the running step as processing until it's polled enough times
+func (s *stepsTestServerState) applyRunningStep(steps []manager.Step) []manager.Step {
+	result := append([]manager.Step{}, steps...)
+	for i := range result {
+		if result[i].Id == s.runningStepId && s.polls < s.runningPolls {
+			result[i].StatusCode, result[i].EndedAt = 4001, time.Time{}
+		}
+	}
+	if s.runPolls <= s.pendingRunPolls {
+		result = slices.DeleteFunc(result, func(step manager.Step) bool {
+			return step.Id == s.pendingStepId
+		})
+	}
+	return result
+}
@@ -1,8 +1,29 @@
 package commands
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
 	"github.com/jfrog/jfrog-cli-core/v2/pipelines/manager"
+	"github.com/jfrog/jfrog-cli-core/v2/pipelines/status"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/pipelines"
+	"github.com/jfrog/jfrog-client-go/pipelines/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	DefaultWaitTimeout     = 2 * time.Hour
+	DefaultPollInterval    = MinimumIntervalRetriesInMilliSecs * time.Millisecond
+	DefaultMaxPollInterval = time.Minute
+	StepLabel              = "Step :"
 )
 
 type TriggerCommand struct {
@@ -10,10 +31,26 @@ type TriggerCommand struct {
 	branch        string
 	pipelineName  string
 	isMultiBranch bool
+	// Wait for the triggered run to end, and fail with the exit code matching the status it ended with
+	wait        bool
+	waitTimeout time.Duration
+	// Interval between the polls of the run status. It's doubled after each poll without changes, up to the max poll interval
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+	// URL to POST a RunStatusEvent to, on each status change of the triggered run
+	webhookUrl string
+}
+
+// RunStatusEvent is sent to the webhook URL on each status change of the triggered run
+type RunStatusEvent struct {
+	PipelineRunStatus
+	RunId          int                   `json:"runId"`
+	PreviousStatus status.PipelineStatus `json:"previousStatus,omitempty"`
+	Timestamp      time.Time             `json:"timestamp"`
 }
 
 func NewTriggerCommand() *TriggerCommand {
-	return &TriggerCommand{}
+	return &TriggerCommand{waitTimeout: DefaultWaitTimeout, pollInterval: DefaultPollInterval, maxPollInterval: DefaultMaxPollInterval}
 }
 
 func (tc *TriggerCommand) ServerDetails() (*config.ServerDetails, error) {
@@ -40,6 +77,28 @@ func (tc *TriggerCommand) SetMultiBranch(multiBranch bool) *TriggerCommand {
 	return tc
 }
 
+func (tc *TriggerCommand) SetWait(wait bool) *TriggerCommand {
+	tc.wait = wait
+	return tc
+}
+
+func (tc *TriggerCommand) SetWaitTimeout(waitTimeout time.Duration) *TriggerCommand {
+	tc.waitTimeout = waitTimeout
+	return tc
+}
+
+// SetPollInterval sets the initial and the max intervals between the polls of the run status
+func (tc *TriggerCommand) SetPollInterval(pollInterval, maxPollInterval time.Duration) *TriggerCommand {
+	tc.pollInterval = pollInterval
+	tc.maxPollInterval = maxPollInterval
+	return tc
+}
+
+func (tc *TriggerCommand) SetWebhookUrl(webhookUrl string) *TriggerCommand {
+	tc.webhookUrl = webhookUrl
+	return tc
+}
+
 func (tc *TriggerCommand) CommandName() string {
 	return "pl_trigger"
 }
@@ -49,5 +108,151 @@ func (tc *TriggerCommand) Run() error {
 	if err != nil {
 		return err
 	}
-	return serviceManager.TriggerPipelineRun(tc.branch, tc.pipelineName, tc.isMultiBranch)
+	if !tc.wait {
+		return serviceManager.TriggerPipelineRun(tc.branch, tc.pipelineName, tc.isMultiBranch)
+	}
+	if tc.pollInterval <= 0 || tc.maxPollInterval < tc.pollInterval {
+		return errorutils.CheckErrorf("the poll interval must be positive, and not greater than the max poll interval")
+	}
+	// The latest run before triggering, to identify the triggered run
+	pipeline, err := getPipeline(serviceManager, tc.branch, tc.pipelineName, tc.isMultiBranch)
+	if err != nil {
+		return err
+	}
+	previousRunId := 0
+	if pipeline != nil {
+		previousRunId = pipeline.LatestRunID
+	}
+	if err = serviceManager.TriggerPipelineRun(tc.branch, tc.pipelineName, tc.isMultiBranch); err != nil {
+		return err
+	}
+	runsService, err := manager.CreateRunsService(tc.serverDetails, serviceManager)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tc.waitTimeout)
+	defer cancel()
+	return tc.waitForRun(ctx, &runFollower{serviceManager: serviceManager, runsService: runsService, previousRunId: previousRunId, stepStatuses: make(map[int]status.PipelineStatus)})
+}
+
+// getPipeline returns the pipeline, with its latest run, or nil if it isn't found
+func getPipeline(pipelinesMgr *pipelines.PipelinesServicesManager, branch, pipelineName string, isMultiBranch bool) (*services.Pipelines, error) {
+	matchingPipes, err := pipelinesMgr.GetPipelineRunStatusByBranch(branch, pipelineName, isMultiBranch)
+	if err != nil {
+		return nil, err
+	}
+	for i := range matchingPipes.Pipelines {
+		pipe := matchingPipes.Pipelines[i]
+		if pipe.Name == pipelineName && (!isMultiBranch || pipe.PipelineSourceBranch == branch) {
+			return &pipe, nil
+		}
+	}
+	return nil, nil
+}
+
+// waitForRun polls the status of the triggered run until it ends, or until the context is done.
+// Returns a CliError with the exit code matching the status the run ended with, unless it succeeded.
+func (tc *TriggerCommand) waitForRun(ctx context.Context, follower *runFollower) error {
+	interval := tc.pollInterval
+	for {
+		changed, err := tc.pollRun(follower)
+		if err != nil {
+			return err
+		}
+		if status.IsRunEnded(follower.status) {
+			if exitCode := status.GetExitCode(follower.status); exitCode != coreutils.ExitCodeNoError {
+				return coreutils.CliError{ExitCode: exitCode, ErrorMsg: fmt.Sprintf("run %d of pipeline '%s' ended with status %s", follower.pipeline.Run.RunNumber, tc.pipelineName, follower.status)}
+			}
+			return nil
+		}
+		if changed {
+			interval = tc.pollInterval
+		} else {
+			interval = min(2*interval, tc.maxPollInterval)
+		}
+		select {
+		case <-ctx.Done():
+			return coreutils.CliError{ExitCode: status.ExitCodeWaitTimeout, ErrorMsg: fmt.Sprintf("the triggered run of pipeline '%s' didn't end within %s", tc.pipelineName, tc.waitTimeout)}
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Follows the status of the triggered run and of its steps
+type runFollower struct {
+	serviceManager *pipelines.PipelinesServicesManager
+	runsService    *manager.RunsService
+	webhookClient  *httpclient.HttpClient
+	previousRunId  int
+	// The triggered pipeline, with the latest state of the triggered run. Nil until the run is created
+	pipeline     *services.Pipelines
+	status       status.PipelineStatus
+	stepStatuses map[int]status.PipelineStatus
+}
+
+// pollRun prints the status changes of the triggered run and of its steps, and sends the run status changes to the webhook.
+// Returns true if any status changed.
+func (tc *TriggerCommand) pollRun(follower *runFollower) (changed bool, err error) {
+	if follower.pipeline == nil {
+		pipeline, err := getPipeline(follower.serviceManager, tc.branch, tc.pipelineName, tc.isMultiBranch)
+		if err != nil || pipeline == nil || pipeline.LatestRunID == 0 || pipeline.LatestRunID == follower.previousRunId {
+			// The triggered run wasn't created yet
+			return false, err
+		}
+		follower.pipeline = pipeline
+		log.Info(fmt.Sprintf("Waiting for run %d of pipeline '%s' to end", pipeline.Run.RunNumber, pipeline.Name))
+	}
+	run, err := follower.runsService.GetRun(follower.pipeline.LatestRunID)
+	if err != nil {
+		return false, err
+	}
+	follower.pipeline.Run = *run
+	steps, err := follower.runsService.GetRunSteps(run.ID)
+	if err != nil {
+		return false, err
+	}
+	for i := range steps {
+		stepStatus := status.GetPipelineStatus(steps[i].StatusCode)
+		if pipelineStatusChanged(string(stepStatus), string(follower.stepStatuses[steps[i].Id])) {
+			log.Output(status.GetStatusColorCode(stepStatus).Sprintf("%14s %s %s %s", StepLabel, steps[i].Name, StatusLabel, stepStatus))
+			follower.stepStatuses[steps[i].Id] = stepStatus
+			changed = true
+		}
+	}
+	currentStatus := status.GetPipelineStatus(run.StatusCode)
+	if !pipelineStatusChanged(string(currentStatus), string(follower.status)) {
+		return changed, nil
+	}
+	log.Output(getColoredPipelineStatus(follower.pipeline))
+	event := RunStatusEvent{PipelineRunStatus: createRunStatus(follower.pipeline, steps), RunId: run.ID, PreviousStatus: follower.status, Timestamp: time.Now()}
+	follower.status = currentStatus
+	tc.sendWebhookEvent(follower, &event)
+	return true, nil
+}
+
+// sendWebhookEvent posts the event to the webhook URL, if set. Failures are logged, without failing the wait.
+func (tc *TriggerCommand) sendWebhookEvent(follower *runFollower, event *RunStatusEvent) {
+	if tc.webhookUrl == "" {
+		return
+	}
+	content, err := json.Marshal(event)
+	if err != nil {
+		log.Warn("Failed to create the webhook event:", err.Error())
+		return
+	}
+	if follower.webhookClient == nil {
+		if follower.webhookClient, err = httpclient.ClientBuilder().SetRetries(3).Build(); err != nil {
+			log.Warn("Failed to create the webhook client:", err.Error())
+			return
+		}
+	}
+	httpClientDetails := httputils.HttpClientDetails{}
+	httpClientDetails.SetContentTypeApplicationJson()
+	resp, body, err := follower.webhookClient.SendPost(tc.webhookUrl, content, httpClientDetails, "")
+	if err == nil {
+		err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent)
+	}
+	if err != nil {
+		log.Warn("Failed to send the run status event to the webhook:", err.Error())
+	}
 }
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/pipelines/manager"
+	"github.com/jfrog/jfrog-cli-core/v2/pipelines/status"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/pipelines/services"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slices"
+)
+
+func TestTriggerAndWait(t *testing.T) {
+	testCases := []struct {
+		name             string
+		runStatusCodes   []int
+		expectedExitCode coreutils.ExitCode
+	}{
+		{"success", []int{4000, 4001, 4002}, coreutils.ExitCodeNoError},
+		{"failure", []int{4001, 4003}, status.ExitCodeFailure},
+		{"cancelled", []int{4001, 4001, 4006}, status.ExitCodeCancelled},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			serverDetails := createTriggerTestServer(t, testCase.runStatusCodes)
+			var events []RunStatusEvent
+			webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				content, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				var event RunStatusEvent
+				assert.NoError(t, json.Unmarshal(content, &event))
+				events = append(events, event)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer webhookServer.Close()
+
+			var err error
+			output := captureOutput(t, func() {
+				err = NewTriggerCommand().SetServerDetails(serverDetails).SetPipelineName("build").SetWait(true).
+					SetPollInterval(time.Millisecond, 4*time.Millisecond).SetWebhookUrl(webhookServer.URL).Run()
+			})
+			assertExitCode(t, testCase.expectedExitCode, err)
+			assert.Contains(t, output, "Step : compile")
+
+			// Each status change is sent once, and only the statuses of the triggered run are sent
+			var expectedStatuses []status.PipelineStatus
+			for _, statusCode := range testCase.runStatusCodes {
+				if pipelineStatus := status.GetPipelineStatus(statusCode); !slices.Contains(expectedStatuses, pipelineStatus) {
+					expectedStatuses = append(expectedStatuses, pipelineStatus)
+				}
+			}
+			var actualStatuses []status.PipelineStatus
+			for i, event := range events {
+				assert.Equal(t, 2, event.RunId)
+				assert.Equal(t, "build", event.Pipeline)
+				if i > 0 {
+					assert.Equal(t, events[i-1].Status, event.PreviousStatus)
+				}
+				actualStatuses = append(actualStatuses, event.Status)
+			}
+			assert.Equal(t, expectedStatuses, actualStatuses)
+		})
+	}
+}
+
+func TestTriggerWaitTimeout(t *testing.T) {
+	serverDetails := createTriggerTestServer(t, []int{4001})
+	err := NewTriggerCommand().SetServerDetails(serverDetails).SetPipelineName("build").SetWait(true).
+		SetPollInterval(time.Millisecond, time.Millisecond).SetWaitTimeout(20 * time.Millisecond).Run()
+	assertExitCode(t, status.ExitCodeWaitTimeout, err)
+}
+
+func TestTriggerInvalidPollInterval(t *testing.T) {
+	serverDetails := createTriggerTestServer(t, []int{4002})
+	err := NewTriggerCommand().SetServerDetails(serverDetails).SetPipelineName("build").SetWait(true).
+		SetPollInterval(time.Minute, time.Second).Run()
+	assert.ErrorContains(t, err, "the poll interval must be positive")
+}
+
+func assertExitCode(t *testing.T, expected coreutils.ExitCode, err error) {
+	if expected == coreutils.ExitCodeNoError {
+		assert.NoError(t, err)
+		return
+	}
+	var cliError coreutils.CliError
+	if assert.True(t, errors.As(err, &cliError)) {
+		assert.Equal(t, expected, cliError.ExitCode)
+	}
+}
+
+// Create a pipelines server, in which the 'build' pipeline has run 1, until it's triggered.
+// The triggered run 2 has the given statuses, one per poll, and stays with the last status.
+func createTriggerTestServer(t *testing.T, runStatusCodes []int) *config.ServerDetails {
+	var mutex sync.Mutex
+	triggered := false
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		var content any
+		switch r.URL.Path {
+		case "/api/v1/pipelines/trigger":
+			triggered = true
+			content = map[string]string{}
+		case "/api/v1/search/pipelines/":
+			pipeline := services.Pipelines{Name: "build", PipelineSourceBranch: "main", LatestRunID: 1, Run: services.Run{ID: 1, RunNumber: 1, StatusCode: 4002}}
+			if triggered {
+				pipeline.LatestRunID, pipeline.Run = 2, services.Run{ID: 2, RunNumber: 2, StatusCode: runStatusCodes[0]}
+			}
+			content = services.PipelineRunStatusResponse{TotalCount: 1, Pipelines: []services.Pipelines{pipeline}}
+		case "/api/v1/runs/2":
+			content = services.Run{ID: 2, RunNumber: 2, StatusCode: runStatusCodes[min(polls, len(runStatusCodes)-1)], CreatedAt: time.Now()}
+			polls++
+		case "/api/v1/steps":
+			assert.Equal(t, "2", r.URL.Query().Get("runIds"))
+			content = []manager.Step{{Id: 1, Name: "compile", StatusCode: runStatusCodes[min(polls, len(runStatusCodes))-1]}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := json.Marshal(content)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(body)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+	return &config.ServerDetails{PipelinesUrl: server.URL + "/", AccessToken: "token"}
+}
@@ -8,18 +8,22 @@ import (
 
 // CreateServiceManager creates pipelines manager and set auth details
 func CreateServiceManager(serviceDetails *utilsconfig.ServerDetails) (*pipelines.PipelinesServicesManager, error) {
+	serviceConfig, err := createServiceConfig(serviceDetails)
+	if err != nil {
+		return nil, err
+	}
+	return pipelines.New(serviceConfig)
+}
+
+func createServiceConfig(serviceDetails *utilsconfig.ServerDetails) (clientConfig.Config, error) {
 	pipelinesDetails := *serviceDetails
 	// Create pipelines authentication config
 	pAuth, err := pipelinesDetails.CreatePipelinesAuthConfig()
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	return clientConfig.NewConfigBuilder().
 		SetServiceDetails(pAuth).
 		SetDryRun(false).
 		Build()
-	if err != nil {
-		return nil, err
-	}
-	return pipelines.New(serviceConfig)
 }
@@ -0,0 +1,152 @@
+package manager
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	utilsconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	"github.com/jfrog/jfrog-client-go/pipelines"
+	"github.com/jfrog/jfrog-client-go/pipelines/services"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	runsPath         = "api/v1/runs"
+	stepsPath        = "api/v1/steps"
+	stepConsolesPath = "/consoles"
+	consoleTypeGroup = "grp"
+)
+
+// RunsService fetches the runs of pipelines and their steps, which aren't covered by the pipelines services manager.
+type RunsService struct {
+	client         *jfroghttpclient.JfrogHttpClient
+	serviceDetails auth.ServiceDetails
+}
+
+// A step of a pipeline run, as returned by the pipelines steps API.
+type Step struct {
+	Id         int       `json:"id"`
+	Name       string    `json:"name"`
+	RunId      int       `json:"runId"`
+	StatusCode int       `json:"statusCode"`
+	CreatedAt  time.Time `json:"createdAt"`
+	StartedAt  time.Time `json:"startedAt"`
+	EndedAt    time.Time `json:"endedAt"`
+}
+
+// A console log entry of a step, as returned by the pipelines step consoles API.
+type Console struct {
+	ConsoleId       string `json:"consoleId"`
+	ParentConsoleId string `json:"parentConsoleId"`
+	Type            string `json:"type"`
+	Message         string `json:"message"`
+	IsSuccess       *bool  `json:"isSuccess"`
+	// Microseconds since epoch
+	Timestamp int64 `json:"timestamp"`
+}
+
+// IsGroup returns true if the console is a group of other consoles, such as a section of the step execution.
+func (c *Console) IsGroup() bool {
+	return c.Type == consoleTypeGroup
+}
+
+// DurationSeconds returns the time the step has been running, or 0 if it hasn't started yet.
+// The duration of a step, which hasn't ended yet, is calculated until now.
+func (s *Step) DurationSeconds() int {
+	if s.StartedAt.IsZero() {
+		return 0
+	}
+	endedAt := s.EndedAt
+	if endedAt.IsZero() {
+		endedAt = time.Now()
+	}
+	return int(endedAt.Sub(s.StartedAt).Seconds())
+}
+
+// CreateRunsService creates a runs service, which uses the client of the pipelines services manager.
+func CreateRunsService(serviceDetails *utilsconfig.ServerDetails, servicesManager *pipelines.PipelinesServicesManager) (*RunsService, error) {
+	serviceConfig, err := createServiceConfig(serviceDetails)
+	if err != nil {
+		return nil, err
+	}
+	return &RunsService{client: servicesManager.Client(), serviceDetails: serviceConfig.GetServiceDetails()}, nil
+}
+
+// GetRun returns the pipeline run by its ID.
+func (rs *RunsService) GetRun(runId int) (*services.Run, error) {
+	run := &services.Run{}
+	if err := rs.sendGet(runsPath+"/"+strconv.Itoa(runId), nil, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// GetRunByNumber returns the run of a pipeline by its run number, or nil if it isn't found.
+func (rs *RunsService) GetRunByNumber(pipelineId, runNumber int) (*services.Run, error) {
+	var runs []services.Run
+	queryParams := map[string]string{"pipelineIds": strconv.Itoa(pipelineId), "runNumbers": strconv.Itoa(runNumber)}
+	if err := rs.sendGet(runsPath, queryParams, &runs); err != nil {
+		return nil, err
+	}
+	for i := range runs {
+		if runs[i].RunNumber == runNumber {
+			return &runs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GetStep returns the step by its ID.
+func (rs *RunsService) GetStep(stepId int) (*Step, error) {
+	step := &Step{}
+	if err := rs.sendGet(stepsPath+"/"+strconv.Itoa(stepId), nil, step); err != nil {
+		return nil, err
+	}
+	return step, nil
+}
+
+// GetStepConsoles returns the console logs of a step, ordered by their time.
+func (rs *RunsService) GetStepConsoles(stepId int) ([]Console, error) {
+	var consoles []Console
+	if err := rs.sendGet(stepsPath+"/"+strconv.Itoa(stepId)+stepConsolesPath, nil, &consoles); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(consoles, func(i, j int) bool {
+		return consoles[i].Timestamp < consoles[j].Timestamp
+	})
+	return consoles, nil
+}
+
+// GetRunSteps returns the steps of a pipeline run, ordered by their creation.
+func (rs *RunsService) GetRunSteps(runId int) ([]Step, error) {
+	var steps []Step
+	if err := rs.sendGet(stepsPath, map[string]string{"runIds": strconv.Itoa(runId)}, &steps); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].Id < steps[j].Id
+	})
+	return steps, nil
+}
+
+func (rs *RunsService) sendGet(path string, queryParams map[string]string, result any) error {
+	httpDetails := rs.serviceDetails.CreateHttpClientDetails()
+	uri, err := utils.BuildUrl(utils.AddTrailingSlashIfNeeded(rs.serviceDetails.GetUrl()), path, queryParams)
+	if err != nil {
+		return err
+	}
+	resp, body, _, err := rs.client.SendGet(uri, true, &httpDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return err
+	}
+	return errorutils.CheckError(json.Unmarshal(body, result))
+}
@@ -1,6 +1,12 @@
 package status
 
-import "github.com/gookit/color"
+import (
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
 
 type PipelineStatus string
 
@@ -97,3 +103,70 @@ func GetPipelineStatus(statusCode int) PipelineStatus {
 	}
 	return NOTDEFINED
 }
+
+const (
+	minStatusCode = 4000
+	maxStatusCode = 4022
+)
+
+// ParsePipelineStatus returns the pipeline status matching the name, ignoring the case
+// for eq:- "timingout" returns TIMINGOUT
+func ParsePipelineStatus(name string) (PipelineStatus, error) {
+	var names []string
+	for statusCode := minStatusCode; statusCode <= maxStatusCode; statusCode++ {
+		pipelineStatus := GetPipelineStatus(statusCode)
+		if strings.EqualFold(string(pipelineStatus), strings.TrimSpace(name)) {
+			return pipelineStatus, nil
+		}
+		names = append(names, string(pipelineStatus))
+	}
+	return NOTDEFINED, errorutils.CheckErrorf("unknown pipeline status '%s'. The supported statuses are: %s", name, strings.Join(names, ", "))
+}
+
+// Exit codes of commands waiting for a pipeline run to end, by the status the run ended with
+var (
+	ExitCodeFailure   = coreutils.ExitCode{Code: 10}
+	ExitCodeError     = coreutils.ExitCode{Code: 11}
+	ExitCodeCancelled = coreutils.ExitCode{Code: 12}
+	ExitCodeTimeout   = coreutils.ExitCode{Code: 13}
+	ExitCodeUnstable  = coreutils.ExitCode{Code: 14}
+	ExitCodeStopped   = coreutils.ExitCode{Code: 15}
+	ExitCodeSkipped   = coreutils.ExitCode{Code: 16}
+	// The run didn't end before the wait timeout
+	ExitCodeWaitTimeout = coreutils.ExitCode{Code: 17}
+)
+
+// IsRunEnded returns true if a pipeline run with the status is done
+// and its status won't change anymore
+func IsRunEnded(status PipelineStatus) bool {
+	switch status {
+	case SUCCESS, FAILURE, ERROR, CANCELLED, TIMEOUT, UNSTABLE, STOPPED, SKIPPED:
+		return true
+	}
+	return false
+}
+
+// GetExitCode returns the exit code matching the status a pipeline run ended with
+// for eq:- failure returns ExitCodeFailure
+// ExitCodeWaitTimeout is returned if the run hasn't ended
+func GetExitCode(status PipelineStatus) coreutils.ExitCode {
+	switch status {
+	case SUCCESS:
+		return coreutils.ExitCodeNoError
+	case FAILURE:
+		return ExitCodeFailure
+	case ERROR:
+		return ExitCodeError
+	case CANCELLED:
+		return ExitCodeCancelled
+	case TIMEOUT:
+		return ExitCodeTimeout
+	case UNSTABLE:
+		return ExitCodeUnstable
+	case STOPPED:
+		return ExitCodeStopped
+	case SKIPPED:
+		return ExitCodeSkipped
+	}
+	return ExitCodeWaitTimeout
+}
@@ -2,6 +2,8 @@ package status
 
 import (
 	"github.com/gookit/color"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -69,3 +71,27 @@ func TestGetPipelineStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePipelineStatus(t *testing.T) {
+	pipelineStatus, err := ParsePipelineStatus("Success")
+	assert.NoError(t, err)
+	assert.Equal(t, SUCCESS, pipelineStatus)
+
+	pipelineStatus, err = ParsePipelineStatus("timingout")
+	assert.NoError(t, err)
+	assert.Equal(t, TIMINGOUT, pipelineStatus)
+
+	_, err = ParsePipelineStatus("done")
+	assert.ErrorContains(t, err, "unknown pipeline status 'done'")
+}
+
+func TestGetExitCode(t *testing.T) {
+	assert.Equal(t, coreutils.ExitCodeNoError, GetExitCode(SUCCESS))
+	assert.Equal(t, ExitCodeFailure, GetExitCode(FAILURE))
+	assert.Equal(t, ExitCodeCancelled, GetExitCode(CANCELLED))
+	assert.Equal(t, ExitCodeWaitTimeout, GetExitCode(PROCESSING))
+	for _, pipelineStatus := range []PipelineStatus{SUCCESS, FAILURE, ERROR, CANCELLED, TIMEOUT, UNSTABLE, STOPPED, SKIPPED} {
+		assert.True(t, IsRunEnded(pipelineStatus))
+	}
+	assert.False(t, IsRunEnded(WAITING))
+}
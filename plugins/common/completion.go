@@ -0,0 +1,38 @@
+package common
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Complete the IDs of the configured servers.
+func CompleteServerIds(*components.Context) []string {
+	return commands.GetAllServerIds()
+}
+
+// Complete the repository keys of the server, provided by the 'server-id' flag, or of the default server.
+// Errors are only logged in debug, since the completion output is read by the shell.
+func CompleteRepositories(c *components.Context) []string {
+	serverDetails, err := GetServerDetails(c)
+	if err != nil {
+		log.Debug("Failed to complete the repositories:", err.Error())
+		return nil
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		log.Debug("Failed to complete the repositories:", err.Error())
+		return nil
+	}
+	repositories, err := servicesManager.GetAllRepositories()
+	if err != nil {
+		log.Debug("Failed to complete the repositories:", err.Error())
+		return nil
+	}
+	var repoKeys []string
+	for _, repository := range *repositories {
+		repoKeys = append(repoKeys, repository.Key)
+	}
+	return repoKeys
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	cliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/usage"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The keys of the values, which the built-in middlewares share with the command actions.
+const (
+	serverDetailsValueKey = "jfrog-server-details"
+	commandResultValueKey = "jfrog-command-result"
+	usageReporterValueKey = "jfrog-usage-reporter"
+)
+
+// Resolve the server details from the flags, or from the configured servers, before the action runs.
+// The action gets the server details with GetContextServerDetails.
+func ServerDetailsMiddleware(excludeRefreshableTokens bool, domain cliUtils.CommandDomain) components.Middleware {
+	return components.Middleware{
+		Before: func(c *components.Context) error {
+			serverDetails, err := CreateServerDetailsWithConfigOffer(c, excludeRefreshableTokens, domain)
+			if err != nil {
+				return err
+			}
+			c.SetValue(serverDetailsValueKey, serverDetails)
+			return nil
+		},
+	}
+}
+
+// Returns the server details resolved by ServerDetailsMiddleware, or nil.
+func GetContextServerDetails(c *components.Context) *config.ServerDetails {
+	serverDetails, _ := c.GetValue(serverDetailsValueKey).(*config.ServerDetails)
+	return serverDetails
+}
+
+// Set the result of the action, which is used by CommandSummaryMiddleware and FailNoOpMiddleware.
+func SetContextCommandResult(c *components.Context, result *commandUtils.Result) {
+	c.SetValue(commandResultValueKey, result)
+}
+
+func GetContextCommandResult(c *components.Context) *commandUtils.Result {
+	result, _ := c.GetValue(commandResultValueKey).(*commandUtils.Result)
+	return result
+}
+
+// Report the usage of the command, while the action runs.
+// The usage is reported to the server resolved by ServerDetailsMiddleware, which should run first, or to the default server.
+func UsageReportMiddleware(productId string) components.Middleware {
+	waitForReport := func(c *components.Context) {
+		if reporter, ok := c.GetValue(usageReporterValueKey).(*usage.UsageReporter); ok {
+			if err := reporter.WaitForResponses(); err != nil {
+				log.Debug(err.Error())
+			}
+		}
+	}
+	return components.Middleware{
+		Before: func(c *components.Context) error {
+			serverDetails := GetContextServerDetails(c)
+			if serverDetails == nil {
+				var err error
+				if serverDetails, err = config.GetDefaultServerConf(); err != nil {
+					log.Debug("Usage reporting. Failed getting the default server details:", err.Error())
+					return nil
+				}
+			}
+			if serverDetails == nil || serverDetails.ArtifactoryUrl == "" {
+				return nil
+			}
+			reporter := usage.NewUsageReporter(productId, serverDetails)
+			reporter.Report(usage.ReportFeature{FeatureId: c.CommandName})
+			c.SetValue(usageReporterValueKey, reporter)
+			return nil
+		},
+		After: func(c *components.Context) error {
+			waitForReport(c)
+			return nil
+		},
+		OnError: func(c *components.Context, err error) error {
+			waitForReport(c)
+			return err
+		},
+	}
+}
+
+// Print the summary of the result set by the action with SetContextCommandResult, and close its reader.
+// The summary is printed also when the action fails, and the error is converted to the matching exit code, considering the 'fail-no-op' flag.
+func CommandSummaryMiddleware(printDeploymentView bool) components.Middleware {
+	printSummary := func(c *components.Context, originalErr error) (err error) {
+		result := GetContextCommandResult(c)
+		defer CleanupResult(result, &err)
+		return PrintCommandSummary(result, GetDetailedSummary(c), printDeploymentView, IsFailNoOp(c), originalErr)
+	}
+	return components.Middleware{
+		After: func(c *components.Context) error {
+			return printSummary(c, nil)
+		},
+		OnError: printSummary,
+	}
+}
+
+// Fail the command with the 'fail-no-op' exit code, if the 'fail-no-op' flag or environment variable is set, and the result set
+// by the action with SetContextCommandResult has no affected files. Use it for commands, which don't print a summary.
+func FailNoOpMiddleware() components.Middleware {
+	getCliError := func(c *components.Context, err error) error {
+		result := GetContextCommandResult(c)
+		if result == nil {
+			return err
+		}
+		return GetCliError(err, result.SuccessCount(), result.FailCount(), IsFailNoOp(c))
+	}
+	return components.Middleware{
+		After: func(c *components.Context) error {
+			return getCliError(c, nil)
+		},
+		OnError: getCliError,
+	}
+}
@@ -0,0 +1,89 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	cliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/testkit"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// A command, which sets a result with the given counts, and returns the given error.
+func getResultCommand(successCount, failCount int, actionErr error, middlewares ...components.Middleware) components.Command {
+	return components.Command{
+		Name:        "cmd",
+		Flags:       []components.Flag{components.NewBoolFlag("fail-no-op", "Fail if no files are affected.")},
+		Middlewares: middlewares,
+		Action: func(c *components.Context) error {
+			result := new(commandUtils.Result)
+			result.SetSuccessCount(successCount)
+			result.SetFailCount(failCount)
+			SetContextCommandResult(c, result)
+			return actionErr
+		},
+	}
+}
+
+func TestServerDetailsMiddleware(t *testing.T) {
+	var serverDetails *config.ServerDetails
+	command := components.Command{
+		Name:        "cmd",
+		Flags:       []components.Flag{GetServerIdFlag()},
+		Middlewares: []components.Middleware{ServerDetailsMiddleware(true, cliUtils.Rt)},
+		Action: func(c *components.Context) error {
+			serverDetails = GetContextServerDetails(c)
+			return nil
+		},
+	}
+	result := testkit.NewCommandRunner(t, command).SetServerDetails(
+		&config.ServerDetails{ServerId: "first", Url: "https://first.jfrog.io/", ArtifactoryUrl: "https://first.jfrog.io/artifactory/"},
+		&config.ServerDetails{ServerId: "second", Url: "https://second.jfrog.io/", ArtifactoryUrl: "https://second.jfrog.io/artifactory/"},
+	).Run("cmd", "--server-id=second")
+	assert.NoError(t, result.Err)
+	if assert.NotNil(t, serverDetails) {
+		assert.Equal(t, "second", serverDetails.ServerId)
+		assert.Equal(t, "https://second.jfrog.io/artifactory/", serverDetails.ArtifactoryUrl)
+	}
+}
+
+func TestUsageReportMiddlewareWithoutServer(t *testing.T) {
+	// Without a configured server, the usage isn't reported and the command runs as usual
+	command := getResultCommand(1, 0, nil, UsageReportMiddleware("test-plugin"))
+	result := testkit.NewCommandRunner(t, command).Run("cmd")
+	assert.NoError(t, result.Err)
+}
+
+func TestCommandSummaryMiddleware(t *testing.T) {
+	result := testkit.NewCommandRunner(t, getResultCommand(2, 0, nil, CommandSummaryMiddleware(false))).Run("cmd")
+	assert.NoError(t, result.Err)
+	assert.Contains(t, result.Stdout, `"status": "success"`)
+	assert.Contains(t, result.Stdout, `"success": 2`)
+
+	result = testkit.NewCommandRunner(t, getResultCommand(1, 1, errors.New("upload failed"), CommandSummaryMiddleware(false))).Run("cmd")
+	assert.EqualError(t, result.Err, "upload failed")
+	assert.Equal(t, coreutils.ExitCodeError.Code, result.ExitCode)
+	assert.Contains(t, result.Stdout, `"status": "failure"`)
+
+	result = testkit.NewCommandRunner(t, getResultCommand(0, 0, nil, CommandSummaryMiddleware(false))).Run("cmd", "--fail-no-op")
+	assert.Equal(t, coreutils.ExitCodeFailNoOp.Code, result.ExitCode)
+}
+
+func TestFailNoOpMiddleware(t *testing.T) {
+	result := testkit.NewCommandRunner(t, getResultCommand(0, 0, nil, FailNoOpMiddleware())).Run("cmd", "--fail-no-op")
+	assert.Equal(t, coreutils.ExitCodeFailNoOp.Code, result.ExitCode)
+	assert.Empty(t, result.Stdout)
+
+	result = testkit.NewCommandRunner(t, getResultCommand(0, 0, nil, FailNoOpMiddleware())).Run("cmd")
+	assert.NoError(t, result.Err)
+
+	result = testkit.NewCommandRunner(t, getResultCommand(0, 0, nil, FailNoOpMiddleware())).SetEnv(coreutils.FailNoOp, "true").Run("cmd")
+	assert.Equal(t, coreutils.ExitCodeFailNoOp.Code, result.ExitCode)
+
+	result = testkit.NewCommandRunner(t, getResultCommand(1, 0, nil, FailNoOpMiddleware())).Run("cmd", "--fail-no-op")
+	assert.NoError(t, result.Err)
+}
@@ -39,6 +39,8 @@ type Argument struct {
 	// 	2) cmd-name [cmd options] --flag-replacement=value
 	ReplaceWithFlag string
 	Description     string
+	// Optional. Returns the values suggested by the shell completion for this argument e.g. the configured server IDs.
+	Completion CompletionFunc
 }
 
 type EnvVar struct {
@@ -54,8 +56,11 @@ type Context struct {
 	CommandName      string
 	stringFlags      map[string]string
 	boolFlags        map[string]bool
+	stringSliceFlags map[string][]string
 	PrintCommandHelp func(commandName string) error
 	ParentContext    *Context
+	// Values shared between the middlewares and the action of the command.
+	values map[string]any
 }
 
 func (c *Context) GetStringFlagValue(flagName string) string {
@@ -118,6 +123,9 @@ func (c *Context) IsFlagSet(flagName string) bool {
 	if _, exist := c.stringFlags[flagName]; exist {
 		return true
 	}
+	if _, exist := c.stringSliceFlags[flagName]; exist {
+		return true
+	}
 	_, exist := c.boolFlags[flagName]
 	return exist
 }
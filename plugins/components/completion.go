@@ -0,0 +1,288 @@
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// The hidden command, which is called by the completion scripts to get the completion candidates.
+const CompletionCommandName = "__complete"
+
+type Shell string
+
+const (
+	Bash Shell = "bash"
+	Zsh  Shell = "zsh"
+	Fish Shell = "fish"
+)
+
+var supportedShells = []string{string(Bash), string(Zsh), string(Fish)}
+
+// Returns the dynamic completion candidates of an argument e.g. the configured server IDs.
+// The context holds the flags typed so far, such as --server-id, which may affect the candidates.
+type CompletionFunc func(c *Context) []string
+
+const bashCompletionTemplate = `# bash completion for {{.Program}}
+_{{.FunctionName}}_completions() {
+    local IFS=$'\n'
+    COMPREPLY=($({{.Program}} {{.CompleteCommand}} "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _{{.FunctionName}}_completions {{.Program}}
+`
+
+const zshCompletionTemplate = `#compdef {{.Program}}
+_{{.FunctionName}}() {
+    local -a completions
+    completions=("${(@f)$({{.Program}} {{.CompleteCommand}} "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+    compadd -a completions
+}
+compdef _{{.FunctionName}} {{.Program}}
+`
+
+const fishCompletionTemplate = `# fish completion for {{.Program}}
+function __{{.FunctionName}}_complete
+    set -l tokens (commandline -opc) (commandline -ct)
+    {{.Program}} {{.CompleteCommand}} $tokens[2..-1] 2>/dev/null
+end
+complete -c {{.Program}} -f -a '(__{{.FunctionName}}_complete)'
+`
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// Generate the completion script of the app for the given shell.
+// The script calls the hidden completion command of the app, which is added by AddCompletionCommands, to get the completion candidates.
+func GenerateCompletionScript(app App, shell Shell) (string, error) {
+	var scriptTemplate string
+	switch shell {
+	case Bash:
+		scriptTemplate = bashCompletionTemplate
+	case Zsh:
+		scriptTemplate = zshCompletionTemplate
+	case Fish:
+		scriptTemplate = fishCompletionTemplate
+	default:
+		return "", fmt.Errorf("unsupported shell '%s'. Supported shells: %s", shell, strings.Join(supportedShells, ", "))
+	}
+	var script bytes.Buffer
+	err := template.Must(template.New(string(shell)).Parse(scriptTemplate)).Execute(&script, struct {
+		Program, FunctionName, CompleteCommand string
+	}{app.Name, nonIdentifierChars.ReplaceAllString(app.Name, "_"), CompletionCommandName})
+	return script.String(), err
+}
+
+// Add the 'completion' command, which prints the completion script of the app, and the hidden command, which is called by the script.
+func AddCompletionCommands(app *App) {
+	app.Commands = append(app.Commands,
+		Command{
+			Name:        "completion",
+			Description: "Print the shell completion script.",
+			internal:    true,
+			Arguments: []Argument{{
+				Name:        "shell",
+				Description: "The shell to generate the completion script for: " + strings.Join(supportedShells, ", ") + ".",
+				Completion:  func(*Context) []string { return supportedShells },
+			}},
+			Action: func(c *Context) error {
+				if len(c.Arguments) != 1 {
+					return fmt.Errorf("wrong number of arguments (%d). Expected the shell name: %s", len(c.Arguments), strings.Join(supportedShells, ", "))
+				}
+				script, err := GenerateCompletionScript(*app, Shell(c.Arguments[0]))
+				if err != nil {
+					return err
+				}
+				fmt.Print(script)
+				return nil
+			},
+		},
+		Command{
+			Name:            CompletionCommandName,
+			Hidden:          true,
+			internal:        true,
+			SkipFlagParsing: true,
+			Action: func(c *Context) error {
+				for _, candidate := range GetCompletions(*app, c.Arguments) {
+					fmt.Println(candidate)
+				}
+				return nil
+			},
+		},
+	)
+}
+
+// Get the completion candidates for the words typed after the app name. The last word is the word being completed.
+func GetCompletions(app App, words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current, preceding := words[len(words)-1], words[:len(words)-1]
+	commands, namespaces := app.Commands, app.Subcommands
+	for i, word := range preceding {
+		if namespace := findNamespace(namespaces, word); namespace != nil {
+			commands, namespaces = namespace.Commands, nil
+			continue
+		}
+		if command := findCommand(commands, word); command != nil {
+			return getCommandCompletions(command, preceding[i+1:], current)
+		}
+		// Unknown words, such as global flags, are skipped
+	}
+	var candidates []string
+	for _, namespace := range namespaces {
+		if !namespace.Hidden {
+			candidates = append(candidates, namespace.Name)
+		}
+	}
+	for _, command := range commands {
+		if !command.Hidden {
+			candidates = append(candidates, command.Name)
+		}
+	}
+	return filterByPrefix(candidates, current)
+}
+
+func findNamespace(namespaces []Namespace, name string) *Namespace {
+	for i := range namespaces {
+		if namespaces[i].Name == name {
+			return &namespaces[i]
+		}
+	}
+	return nil
+}
+
+func findCommand(commands []Command, name string) *Command {
+	for i := range commands {
+		if commands[i].Name == name || slices.Contains(commands[i].Aliases, name) {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+func findFlag(flags []Flag, name string) Flag {
+	for _, flag := range flags {
+		if flag.GetName() == name {
+			return flag
+		}
+	}
+	return nil
+}
+
+// Complete the flags, the values of enum flags, or the arguments of the command.
+func getCommandCompletions(command *Command, commandWords []string, current string) []string {
+	// The flags typed so far are passed to the dynamic completions
+	completionContext := &Context{CommandName: command.Name}
+	var arguments []string
+	// The flag, which expects its value in the next word, and the flag of the previous word
+	var pendingFlag, previousFlag Flag
+	for _, word := range commandWords {
+		// Bash splits '--flag=value' into the words '--flag', '=' and 'value'
+		if word == "=" && previousFlag != nil {
+			pendingFlag, previousFlag = previousFlag, nil
+			continue
+		}
+		previousFlag = nil
+		if pendingFlag != nil {
+			addCompletionFlag(completionContext, pendingFlag, word)
+			pendingFlag = nil
+			continue
+		}
+		if !strings.HasPrefix(word, "-") {
+			arguments = append(arguments, word)
+			continue
+		}
+		flagName, value, hasValue := strings.Cut(strings.TrimLeft(word, "-"), "=")
+		flag := findFlag(command.Flags, flagName)
+		switch {
+		case flag == nil:
+		case hasValue:
+			addCompletionFlag(completionContext, flag, value)
+		case isValueFlag(flag):
+			pendingFlag, previousFlag = flag, flag
+		default:
+			completionContext.AddBoolFlag(flagName, true)
+			previousFlag = flag
+		}
+	}
+
+	// The value of a flag. When Bash completes '--flag=', the current word is '=', and the value is completed after it.
+	if current == "=" && previousFlag != nil {
+		pendingFlag, current = previousFlag, ""
+	}
+	if pendingFlag != nil {
+		return filterByPrefix(getFlagValues(pendingFlag), current)
+	}
+	if strings.HasPrefix(current, "-") {
+		if flagName, _, hasValue := strings.Cut(strings.TrimLeft(current, "-"), "="); hasValue {
+			var candidates []string
+			for _, value := range getFlagValues(findFlag(command.Flags, flagName)) {
+				candidates = append(candidates, "--"+flagName+"="+value)
+			}
+			return filterByPrefix(candidates, current)
+		}
+		candidates := []string{"--help"}
+		for _, flag := range command.Flags {
+			if !isHiddenFlag(flag) {
+				candidates = append(candidates, "--"+flag.GetName())
+			}
+		}
+		return filterByPrefix(candidates, current)
+	}
+
+	// The next argument
+	if len(arguments) >= len(command.Arguments) || command.Arguments[len(arguments)].Completion == nil {
+		return nil
+	}
+	completionContext.Arguments = arguments
+	return filterByPrefix(command.Arguments[len(arguments)].Completion(completionContext), current)
+}
+
+func addCompletionFlag(completionContext *Context, flag Flag, value string) {
+	if isValueFlag(flag) {
+		completionContext.AddStringFlag(flag.GetName(), value)
+		return
+	}
+	boolValue, err := strconv.ParseBool(value)
+	completionContext.AddBoolFlag(flag.GetName(), err != nil || boolValue)
+}
+
+// Returns true if the flag expects a value, which may be provided as the next word.
+func isValueFlag(flag Flag) bool {
+	_, isBool := flag.(BoolFlag)
+	return !isBool
+}
+
+func isHiddenFlag(flag Flag) bool {
+	switch actualType := flag.(type) {
+	case StringFlag:
+		return actualType.Hidden
+	case BoolFlag:
+		return actualType.Hidden
+	case validatedStringFlag:
+		return actualType.toStringFlag().Hidden
+	case StringSliceFlag:
+		return actualType.Hidden
+	}
+	return false
+}
+
+func getFlagValues(flag Flag) []string {
+	if enumFlag, ok := flag.(EnumFlag); ok {
+		return enumFlag.AllowedValues
+	}
+	return nil
+}
+
+func filterByPrefix(candidates []string, prefix string) []string {
+	var filtered []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,102 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createCompletionTestApp() App {
+	app := CreateEmbeddedApp("test-app", []Command{
+		{
+			Name:    "deploy",
+			Aliases: []string{"d"},
+			Flags: []Flag{
+				NewStringFlag("server-id", ""),
+				NewBoolFlag("dry-run", ""),
+				NewEnumFlag("format", "", []string{"table", "json"}),
+				NewStringFlag("secret", "", SetHiddenStrFlag()),
+			},
+			Arguments: []Argument{
+				{Name: "source"},
+				{Name: "repository", Completion: func(c *Context) []string {
+					return []string{c.GetStringFlagValue("server-id") + "-local", c.GetStringFlagValue("server-id") + "-remote"}
+				}},
+			},
+		},
+		{Name: "delete"},
+		{Name: "hidden", Hidden: true},
+	}, Namespace{Name: "config", Commands: []Command{{Name: "show"}, {Name: "set"}}})
+	AddCompletionCommands(&app)
+	return app
+}
+
+func TestGetCompletions(t *testing.T) {
+	app := createCompletionTestApp()
+	tests := []struct {
+		name     string
+		words    []string
+		expected []string
+	}{
+		{"commands", []string{""}, []string{"config", "deploy", "delete", "completion"}},
+		{"commands prefix", []string{"de"}, []string{"deploy", "delete"}},
+		{"namespace commands", []string{"config", "s"}, []string{"show", "set"}},
+		{"flags", []string{"deploy", "--"}, []string{"--help", "--server-id", "--dry-run", "--format"}},
+		{"flags by alias", []string{"d", "--d"}, []string{"--dry-run"}},
+		{"enum values", []string{"deploy", "--format", "j"}, []string{"json"}},
+		{"enum values with equals", []string{"deploy", "--format="}, []string{"--format=table", "--format=json"}},
+		// Bash splits '--flag=value' at the '=' into separate words
+		{"enum values after a separate equals", []string{"deploy", "--format", "="}, []string{"table", "json"}},
+		{"enum values prefix after a separate equals", []string{"deploy", "--format", "=", "j"}, []string{"json"}},
+		{"dynamic argument with separate equals", []string{"deploy", "--server-id", "=", "prod", "--dry-run", "=", "false", "file.zip", ""}, []string{"prod-local", "prod-remote"}},
+		{"no completion for the first argument", []string{"deploy", "--dry-run", ""}, nil},
+		{"dynamic argument", []string{"deploy", "--server-id=prod", "--dry-run", "file.zip", ""}, []string{"prod-local", "prod-remote"}},
+		{"dynamic argument with flag value", []string{"deploy", "--server-id", "dev", "file.zip", "dev-r"}, []string{"dev-remote"}},
+		{"no more arguments", []string{"deploy", "file.zip", "repo", ""}, nil},
+		{"shells", []string{"completion", "f"}, []string{"fish"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, GetCompletions(app, test.words))
+		})
+	}
+}
+
+func TestGenerateCompletionScript(t *testing.T) {
+	app := createCompletionTestApp()
+	script, err := GenerateCompletionScript(app, Bash)
+	assert.NoError(t, err)
+	assert.Contains(t, script, `COMPREPLY=($(test-app __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))`)
+	assert.Contains(t, script, "complete -o default -F _test_app_completions test-app")
+
+	script, err = GenerateCompletionScript(app, Zsh)
+	assert.NoError(t, err)
+	assert.Contains(t, script, "#compdef test-app")
+
+	script, err = GenerateCompletionScript(app, Fish)
+	assert.NoError(t, err)
+	assert.Contains(t, script, "complete -c test-app -f -a '(__test_app_complete)'")
+
+	_, err = GenerateCompletionScript(app, "powershell")
+	assert.ErrorContains(t, err, "unsupported shell 'powershell'")
+}
+
+func TestCompletionCommandsSkipAppMiddlewares(t *testing.T) {
+	app := createCompletionTestApp()
+	middlewareRuns := 0
+	app.Middlewares = []Middleware{{Before: func(c *Context) error {
+		middlewareRuns++
+		return nil
+	}}}
+	app.Commands[1].Action = func(c *Context) error { return nil }
+	cliApp, err := ConvertApp(app)
+	assert.NoError(t, err)
+
+	assert.NoError(t, cliApp.Run([]string{"test-app", CompletionCommandName, "deploy", "--"}))
+	assert.NoError(t, cliApp.Run([]string{"test-app", "completion", "bash"}))
+	assert.Zero(t, middlewareRuns)
+
+	// Other commands run the app middlewares
+	assert.NoError(t, cliApp.Run([]string{"test-app", "delete"}))
+	assert.Equal(t, 1, middlewareRuns)
+}
@@ -27,11 +27,11 @@ func ConvertApp(jfrogApp App) (*cli.App, error) {
 }
 
 func ConvertAppCommands(jfrogApp App, commandPrefix ...string) (cmds []cli.Command, err error) {
-	cmds, err = convertCommands(jfrogApp.Commands, commandPrefix...)
+	cmds, err = convertCommands(withParentMiddlewares(jfrogApp.Commands, jfrogApp.Middlewares), commandPrefix...)
 	if err != nil || len(jfrogApp.Subcommands) == 0 {
 		return
 	}
-	subcommands, err := convertSubcommands(jfrogApp.Subcommands, commandPrefix...)
+	subcommands, err := convertSubcommands(jfrogApp.Subcommands, jfrogApp.Middlewares, commandPrefix...)
 	if err != nil {
 		return
 	}
@@ -39,7 +39,7 @@ func ConvertAppCommands(jfrogApp App, commandPrefix ...string) (cmds []cli.Comma
 	return
 }
 
-func convertSubcommands(subcommands []Namespace, nameSpaces ...string) ([]cli.Command, error) {
+func convertSubcommands(subcommands []Namespace, appMiddlewares []Middleware, nameSpaces ...string) ([]cli.Command, error) {
 	var converted []cli.Command
 	for _, ns := range subcommands {
 		nameSpaceCommand := cli.Command{
@@ -48,7 +48,8 @@ func convertSubcommands(subcommands []Namespace, nameSpaces ...string) ([]cli.Co
 			Hidden:   ns.Hidden,
 			Category: ns.Category,
 		}
-		nsCommands, err := convertCommands(ns.Commands, append(nameSpaces, ns.Name)...)
+		nsMiddlewares := append(append([]Middleware{}, appMiddlewares...), ns.Middlewares...)
+		nsCommands, err := convertCommands(withParentMiddlewares(ns.Commands, nsMiddlewares), append(nameSpaces, ns.Name)...)
 		if err != nil {
 			return converted, err
 		}
@@ -240,9 +241,10 @@ func getFlagUsagePart(cmd Command, convertedStringFlags map[string]StringFlag, f
 	if mandatoryFlagCount == 0 {
 		return
 	}
-	// Add mandatory flags.
-	for flagName, flag := range convertedStringFlags {
-		if flag.Mandatory {
+	// Add mandatory flags, in the order they are defined, so that the usages are stable.
+	for _, cmdFlag := range cmd.Flags {
+		flagName := cmdFlag.GetName()
+		if flag, exists := convertedStringFlags[flagName]; exists && flag.Mandatory {
 			valueAlias := "value"
 			if flag.HelpValue != "" {
 				valueAlias = flag.HelpValue
@@ -327,6 +329,13 @@ func convertByType(flag Flag) (cli.Flag, *StringFlag, error) {
 		return convertStringFlag(actualType), &actualType, nil
 	case BoolFlag:
 		return convertBoolFlag(actualType), nil, nil
+	case StringSliceFlag:
+		stringFlag := actualType.toStringFlag()
+		return convertStringSliceFlag(stringFlag), &stringFlag, nil
+	case validatedStringFlag:
+		// Typed flags are passed as strings, and shown in the help and usages as string flags.
+		stringFlag := actualType.toStringFlag()
+		return convertStringFlag(stringFlag), &stringFlag, nil
 	}
 	return nil, nil, errorutils.CheckErrorf("flag '%s' does not match any known flag type", flag.GetName())
 }
@@ -351,6 +360,16 @@ func convertStringFlag(f StringFlag) cli.Flag {
 	return stringFlag
 }
 
+func convertStringSliceFlag(f StringFlag) cli.Flag {
+	// The usage is built like a string flag usage, since both show the default, mandatory and optional markers the same way.
+	usage := convertStringFlag(f).(cli.StringFlag).Usage
+	return cli.StringSliceFlag{
+		Name:   f.Name,
+		Hidden: f.Hidden,
+		Usage:  usage,
+	}
+}
+
 func convertBoolFlag(f BoolFlag) cli.Flag {
 	if f.DefaultValue {
 		return cli.BoolTFlag{
@@ -373,7 +392,7 @@ func getActionFunc(cmd Command) cli.ActionFunc {
 		if err != nil {
 			return err
 		}
-		return cmd.Action(pluginContext)
+		return runWithMiddlewares(pluginContext, cmd.Middlewares, cmd.Action)
 	}
 }
 
@@ -404,6 +423,7 @@ func getPrintCommandHelpFunc(c *cli.Context) func(commandName string) error {
 func fillFlagMaps(c *Context, baseContext *cli.Context, originalFlags []Flag) error {
 	c.stringFlags = make(map[string]string)
 	c.boolFlags = make(map[string]bool)
+	c.stringSliceFlags = make(map[string][]string)
 
 	// Loop over all plugin's known flags.
 	for _, flag := range originalFlags {
@@ -416,6 +436,28 @@ func fillFlagMaps(c *Context, baseContext *cli.Context, originalFlags []Flag) er
 				c.stringFlags[stringFlag.Name] = finalValue
 			}
 		}
+		if sliceFlag, ok := flag.(StringSliceFlag); ok {
+			values, err := getValueForStringSliceFlag(sliceFlag, baseContext)
+			if err != nil {
+				return err
+			}
+			if len(values) > 0 {
+				c.stringSliceFlags[sliceFlag.Name] = values
+			}
+		}
+		if typedFlag, ok := flag.(validatedStringFlag); ok {
+			stringFlag := typedFlag.toStringFlag()
+			finalValue, err := getValueForStringFlag(stringFlag, baseContext)
+			if err != nil {
+				return err
+			}
+			if finalValue != "" || baseContext.IsSet(stringFlag.Name) {
+				if err = typedFlag.validate(finalValue); err != nil {
+					return err
+				}
+				c.stringFlags[stringFlag.Name] = finalValue
+			}
+		}
 		if boolFlag, ok := flag.(BoolFlag); ok {
 			val := getValueForBoolFlag(boolFlag, baseContext)
 			// Only store the flag if:
@@ -447,6 +489,19 @@ func getValueForStringFlag(f StringFlag, baseContext *cli.Context) (string, erro
 	return "", nil
 }
 
+func getValueForStringSliceFlag(f StringSliceFlag, baseContext *cli.Context) ([]string, error) {
+	if values := baseContext.StringSlice(f.Name); len(values) > 0 {
+		return values, nil
+	}
+	if len(f.DefaultValue) > 0 {
+		return f.DefaultValue, nil
+	}
+	if f.Mandatory {
+		return nil, errors.New("Mandatory flag '" + f.Name + "' is missing")
+	}
+	return nil, nil
+}
+
 func getValueForBoolFlag(f BoolFlag, baseContext *cli.Context) bool {
 	if f.DefaultValue {
 		return baseContext.BoolT(f.Name)
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/urfave/cli"
@@ -302,3 +303,77 @@ func (d DummyFlagValue) String() string {
 func (d DummyFlagValue) Set(value string) error {
 	return nil
 }
+
+func TestConvertTypedFlags(t *testing.T) {
+	tests := []struct {
+		flag     Flag
+		expected string
+	}{
+		{NewIntFlag("threads", "Number of threads.", WithIntFlagDefaultValue(3), WithIntRange(1, 10)), "--threads  \t[Default: 3] Number of threads. Allowed range: 1-10."},
+		{NewIntFlag("retries", "Number of retries.", WithIntMinValue(0)), "--retries  \t[Optional] Number of retries. Minimum value: 0."},
+		{NewIntFlag("depth", "Max depth.", WithIntMaxValue(5)), "--depth  \t[Optional] Max depth. Maximum value: 5."},
+		{NewDurationFlag("timeout", "Timeout.", SetMandatoryDurationFlag()), "--timeout  \t[Mandatory] Timeout."},
+		{NewEnumFlag("format", "Output format.", []string{"table", "json"}, WithEnumDefaultValue("table")), "--format  \t[Default: table] Output format. Allowed values: table, json."},
+		{NewStringSliceFlag("tag", "A tag."), "--tag  \t[Optional] A tag. Can be provided multiple times."},
+	}
+	for _, test := range tests {
+		t.Run(test.flag.GetName(), func(t *testing.T) {
+			converted, convertedString, err := convertByType(test.flag)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, converted.String())
+			assert.Equal(t, test.flag.GetName(), convertedString.Name)
+		})
+	}
+}
+
+func TestTypedFlagsValues(t *testing.T) {
+	flags := []Flag{
+		NewIntFlag("threads", "", WithIntFlagDefaultValue(3), WithIntRange(1, 10)),
+		NewIntFlag("retries", "", WithIntMinValue(0)),
+		NewIntFlag("depth", "", WithIntMaxValue(5)),
+		NewDurationFlag("timeout", "", WithDurationDefaultValue(time.Minute)),
+		NewEnumFlag("format", "", []string{"table", "json"}),
+		NewStringSliceFlag("tag", "", WithStringSliceDefaultValue("latest")),
+	}
+	var pluginContext *Context
+	app, err := ConvertApp(CreateApp("test-app", "1.0.0", "", []Command{{
+		Name:  "cmd",
+		Flags: flags,
+		Action: func(c *Context) error {
+			pluginContext = c
+			return nil
+		},
+	}}))
+	assert.NoError(t, err)
+
+	// Defaults
+	assert.NoError(t, app.Run([]string{"test-app", "cmd"}))
+	threads, err := pluginContext.GetIntFlagValue("threads")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, threads)
+	timeout, err := pluginContext.GetDurationFlagValue("timeout")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, timeout)
+	assert.False(t, pluginContext.IsFlagSet("format"))
+	assert.Equal(t, []string{"latest"}, pluginContext.GetStringSliceFlagValue("tag"))
+
+	// Provided values
+	assert.NoError(t, app.Run([]string{"test-app", "cmd", "--threads=5", "--timeout=1h30m", "--format=json", "--tag=a", "--tag=b"}))
+	threads, err = pluginContext.GetIntFlagValue("threads")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, threads)
+	timeout, err = pluginContext.GetDurationFlagValue("timeout")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, timeout)
+	assert.Equal(t, "json", pluginContext.GetStringFlagValue("format"))
+	assert.Equal(t, []string{"a", "b"}, pluginContext.GetStringSliceFlagValue("tag"))
+
+	// Invalid values
+	assert.ErrorContains(t, app.Run([]string{"test-app", "cmd", "--threads=11"}), "the value of the flag 'threads' must be at most 10, but got 11")
+	assert.ErrorContains(t, app.Run([]string{"test-app", "cmd", "--threads=0"}), "the value of the flag 'threads' must be at least 1, but got 0")
+	assert.ErrorContains(t, app.Run([]string{"test-app", "cmd", "--retries=-1"}), "the value of the flag 'retries' must be at least 0, but got -1")
+	assert.ErrorContains(t, app.Run([]string{"test-app", "cmd", "--depth=6"}), "the value of the flag 'depth' must be at most 5, but got 6")
+	assert.ErrorContains(t, app.Run([]string{"test-app", "cmd", "--threads=many"}), "invalid value 'many' for the int flag 'threads'")
+	assert.ErrorContains(t, app.Run([]string{"test-app", "cmd", "--timeout=soon"}), "invalid value 'soon' for the duration flag 'timeout'")
+	assert.ErrorContains(t, app.Run([]string{"test-app", "cmd", "--format=xml"}), "invalid value 'xml' for the flag 'format'. Allowed values: table, json")
+}
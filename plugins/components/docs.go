@@ -0,0 +1,300 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/urfave/cli"
+)
+
+const manPagesDirName = "man"
+
+// The documentation of a command, as shown in its help.
+type commandDoc struct {
+	// The namespaces and the command name e.g. "ns cmd"
+	fullName    string
+	pageName    string
+	description string
+	aliases     []string
+	usages      []string
+	arguments   []Argument
+	flags       []flagDoc
+	envVars     []EnvVar
+}
+
+type flagDoc struct {
+	name  string
+	usage string
+}
+
+// Generate the documentation of the app into outputDir - a Markdown reference, named after the app, and man pages under the 'man' directory.
+// The usages match the usages shown by the help of the commands. commandPrefix is the same as in ConvertAppCommands.
+// The usages start with executableName e.g. jf, or with the app name if executableName is empty.
+// The function is meant to be called by a program, which runs as a 'go generate' step of the plugin repository, for example:
+//
+//	//go:generate go run ./docs
+func GenerateDocs(app App, outputDir, executableName string, commandPrefix ...string) error {
+	markdown, err := GenerateMarkdownDocs(app, executableName, commandPrefix...)
+	if err != nil {
+		return err
+	}
+	manPages, err := GenerateManPages(app, executableName, commandPrefix...)
+	if err != nil {
+		return err
+	}
+	manDir := filepath.Join(outputDir, manPagesDirName)
+	if err = fileutils.CreateDirIfNotExist(manDir); err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(outputDir, app.Name+".md"), []byte(markdown), 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	for pageName, content := range manPages {
+		if err = os.WriteFile(filepath.Join(manDir, pageName), []byte(content), 0644); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+// Generate a Markdown reference of the app commands. Hidden namespaces, commands and flags are omitted.
+func GenerateMarkdownDocs(app App, executableName string, commandPrefix ...string) (string, error) {
+	docs, err := createCommandDocs(app, executableName, commandPrefix...)
+	if err != nil {
+		return "", err
+	}
+	var markdown strings.Builder
+	markdown.WriteString("# " + app.Name + "\n\n")
+	if app.Description != "" {
+		markdown.WriteString(app.Description + "\n\n")
+	}
+	if app.Version != "" {
+		markdown.WriteString("Version: " + app.Version + "\n\n")
+	}
+	markdown.WriteString("## Commands\n\n")
+	for _, doc := range docs {
+		markdown.WriteString(fmt.Sprintf("* [%s](#%s)\n", doc.fullName, strings.ToLower(strings.ReplaceAll(doc.fullName, " ", "-"))))
+	}
+	for _, doc := range docs {
+		markdown.WriteString("\n### " + doc.fullName + "\n\n")
+		if doc.description != "" {
+			markdown.WriteString(doc.description + "\n\n")
+		}
+		if len(doc.aliases) > 0 {
+			markdown.WriteString("Aliases: `" + strings.Join(doc.aliases, "`, `") + "`\n\n")
+		}
+		markdown.WriteString("#### Usage\n\n```\n" + strings.Join(doc.usages, "\n") + "\n```\n")
+		if len(doc.arguments) > 0 {
+			markdown.WriteString("\n#### Arguments\n\n| Argument | Description |\n| --- | --- |\n")
+			for _, argument := range doc.arguments {
+				markdown.WriteString(fmt.Sprintf("| `%s` | %s |\n", argument.Name, escapeMarkdownTableCell(getArgumentDocDescription(argument))))
+			}
+		}
+		if len(doc.flags) > 0 {
+			markdown.WriteString("\n#### Flags\n\n| Flag | Description |\n| --- | --- |\n")
+			for _, flag := range doc.flags {
+				markdown.WriteString(fmt.Sprintf("| `--%s` | %s |\n", flag.name, escapeMarkdownTableCell(flag.usage)))
+			}
+		}
+		if len(doc.envVars) > 0 {
+			markdown.WriteString("\n#### Environment Variables\n\n| Variable | Default | Description |\n| --- | --- | --- |\n")
+			for _, envVar := range doc.envVars {
+				markdown.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", envVar.Name, escapeMarkdownTableCell(envVar.Default), escapeMarkdownTableCell(envVar.Description)))
+			}
+		}
+	}
+	return markdown.String(), nil
+}
+
+// Generate roff man pages (section 1) - a page for the app, which lists its commands, and a page for every command.
+// Returns the content of the pages by their file names.
+func GenerateManPages(app App, executableName string, commandPrefix ...string) (map[string]string, error) {
+	docs, err := createCommandDocs(app, executableName, commandPrefix...)
+	if err != nil {
+		return nil, err
+	}
+	source := strings.TrimSpace(app.Name + " " + app.Version)
+	pages := make(map[string]string)
+
+	var appPage strings.Builder
+	appPage.WriteString(createManPageHeader(app.Name, source))
+	appPage.WriteString(".SH NAME\n" + escapeRoff(app.Name))
+	if app.Description != "" {
+		appPage.WriteString(" \\- " + escapeRoff(app.Description))
+	}
+	appPage.WriteString("\n.SH SYNOPSIS\n.nf\n" + escapeRoff(strings.Join(append([]string{getDocsExecutableName(app.Name, executableName)}, commandPrefix...), " ")+" <command> [command options] [arguments...]") + "\n.fi\n")
+	appPage.WriteString(".SH COMMANDS\n")
+	for _, doc := range docs {
+		appPage.WriteString(".TP\n\\fB" + escapeRoff(doc.fullName) + "\\fR\n" + escapeRoff(doc.description) + "\n")
+	}
+	appPage.WriteString(".SH SEE ALSO\n")
+	var seeAlso []string
+	for _, doc := range docs {
+		seeAlso = append(seeAlso, "\\fB"+escapeRoff(doc.pageName)+"\\fR(1)")
+	}
+	appPage.WriteString(strings.Join(seeAlso, ",\n") + "\n")
+	pages[app.Name+".1"] = appPage.String()
+
+	for _, doc := range docs {
+		var page strings.Builder
+		page.WriteString(createManPageHeader(doc.pageName, source))
+		page.WriteString(".SH NAME\n" + escapeRoff(doc.pageName))
+		if doc.description != "" {
+			page.WriteString(" \\- " + escapeRoff(doc.description))
+		}
+		page.WriteString("\n.SH SYNOPSIS\n.nf\n")
+		for _, usage := range doc.usages {
+			page.WriteString(escapeRoff(usage) + "\n")
+		}
+		page.WriteString(".fi\n")
+		if doc.description != "" {
+			page.WriteString(".SH DESCRIPTION\n" + escapeRoff(doc.description) + "\n")
+		}
+		if len(doc.aliases) > 0 {
+			page.WriteString(".SH ALIASES\n" + escapeRoff(strings.Join(doc.aliases, ", ")) + "\n")
+		}
+		if len(doc.arguments) > 0 {
+			page.WriteString(".SH ARGUMENTS\n")
+			for _, argument := range doc.arguments {
+				page.WriteString(".TP\n\\fB" + escapeRoff(argument.Name) + "\\fR\n" + escapeRoff(getArgumentDocDescription(argument)) + "\n")
+			}
+		}
+		if len(doc.flags) > 0 {
+			page.WriteString(".SH OPTIONS\n")
+			for _, flag := range doc.flags {
+				page.WriteString(".TP\n\\fB" + escapeRoff("--"+flag.name) + "\\fR\n" + escapeRoff(flag.usage) + "\n")
+			}
+		}
+		if len(doc.envVars) > 0 {
+			page.WriteString(".SH ENVIRONMENT\n")
+			for _, envVar := range doc.envVars {
+				description := envVar.Description
+				if envVar.Default != "" {
+					description = fmt.Sprintf("[Default: %s] %s", envVar.Default, description)
+				}
+				page.WriteString(".TP\n\\fB" + escapeRoff(envVar.Name) + "\\fR\n" + escapeRoff(description) + "\n")
+			}
+		}
+		page.WriteString(".SH SEE ALSO\n\\fB" + escapeRoff(app.Name) + "\\fR(1)\n")
+		pages[doc.pageName+".1"] = page.String()
+	}
+	return pages, nil
+}
+
+// Collect the documentation of the visible commands, in the order of the help - the app commands, and then the namespaces commands.
+func createCommandDocs(app App, executableName string, commandPrefix ...string) (docs []commandDoc, err error) {
+	executableName = getDocsExecutableName(app.Name, executableName)
+	if docs, err = createNamespaceCommandDocs(app.Name, executableName, app.Commands, commandPrefix, nil); err != nil {
+		return
+	}
+	for _, namespace := range app.Subcommands {
+		if namespace.Hidden {
+			continue
+		}
+		namespaceDocs, err := createNamespaceCommandDocs(app.Name, executableName, namespace.Commands, commandPrefix, []string{namespace.Name})
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, namespaceDocs...)
+	}
+	return
+}
+
+func createNamespaceCommandDocs(appName, executableName string, commands []Command, commandPrefix, namespaces []string) (docs []commandDoc, err error) {
+	for _, cmd := range commands {
+		if cmd.Hidden {
+			continue
+		}
+		doc, err := createCommandDoc(appName, executableName, cmd, commandPrefix, namespaces)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return
+}
+
+func createCommandDoc(appName, executableName string, cmd Command, commandPrefix, namespaces []string) (commandDoc, error) {
+	convertedFlags, convertedStringFlags, err := convertFlags(cmd)
+	if err != nil {
+		return commandDoc{}, err
+	}
+	// The usages are created as in the command help, which is created by convertCommand.
+	usages, err := createCommandUsages(cmd, convertedStringFlags, append(append([]string{}, commandPrefix...), namespaces...)...)
+	if err != nil {
+		return commandDoc{}, err
+	}
+	for i := range usages {
+		usages[i] = executableName + " " + usages[i]
+	}
+	doc := commandDoc{
+		fullName:    strings.Join(append(append([]string{}, namespaces...), cmd.Name), " "),
+		pageName:    strings.Join(append(append([]string{appName}, namespaces...), cmd.Name), "-"),
+		description: cmd.Description,
+		aliases:     cmd.Aliases,
+		usages:      usages,
+		arguments:   cmd.Arguments,
+		envVars:     cmd.EnvVars,
+	}
+	for _, flag := range convertedFlags {
+		if name, usage, hidden := getCliFlagDoc(flag); !hidden {
+			doc.flags = append(doc.flags, flagDoc{name: name, usage: usage})
+		}
+	}
+	return doc, nil
+}
+
+// The usages in the docs start with the name of the executable, which runs the app. The default is the app name.
+func getDocsExecutableName(appName, executableName string) string {
+	if executableName != "" {
+		return executableName
+	}
+	return appName
+}
+
+// Get the flag usage, as shown in the command help.
+func getCliFlagDoc(flag cli.Flag) (name, usage string, hidden bool) {
+	switch actualType := flag.(type) {
+	case cli.StringFlag:
+		name, usage, hidden = actualType.Name, actualType.Usage, actualType.Hidden
+	case cli.StringSliceFlag:
+		name, usage, hidden = actualType.Name, actualType.Usage, actualType.Hidden
+	case cli.BoolFlag:
+		name, usage, hidden = actualType.Name, actualType.Usage, actualType.Hidden
+	case cli.BoolTFlag:
+		name, usage, hidden = actualType.Name, actualType.Usage, actualType.Hidden
+	}
+	// The back-quotes hide the value placeholder in the help.
+	return name, strings.TrimSuffix(usage, "` `"), hidden
+}
+
+func getArgumentDocDescription(argument Argument) string {
+	if argument.Optional {
+		return strings.TrimSpace("[Optional] " + argument.Description)
+	}
+	return argument.Description
+}
+
+func createManPageHeader(title, source string) string {
+	return fmt.Sprintf(".TH \"%s\" \"1\" \"\" \"%s\" \"\"\n", strings.ToUpper(escapeRoff(title)), escapeRoff(source))
+}
+
+// Escape text for roff. Lines starting with a control character are prefixed with a zero-width character.
+func escapeRoff(text string) string {
+	text = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(text)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func escapeMarkdownTableCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", "<br>").Replace(text)
+}
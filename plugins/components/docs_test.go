@@ -0,0 +1,68 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getDocsTestApp() App {
+	app := CreateApp("test-app", "1.0.0", "A test app.", []Command{
+		{
+			Name:        "greet",
+			Description: "Greet a user.",
+			Aliases:     []string{"g"},
+			Arguments: []Argument{
+				{Name: "name", Description: "The name of the user."},
+				{Name: "title", Optional: true, Description: "The title of the user."},
+			},
+			Flags: []Flag{
+				NewStringFlag("greeting", "The greeting.", SetMandatory(), WithHelpValue("text")),
+				NewBoolFlag("shout", "Greet loudly | with capital letters."),
+				NewEnumFlag("format", "The output format.", []string{"text", "json"}, WithEnumDefaultValue("text")),
+				NewStringFlag("secret", "A hidden flag.", SetHiddenStrFlag()),
+			},
+			EnvVars: []EnvVar{{Name: "GREET_LANG", Default: "en", Description: "The greeting language."}},
+		},
+		{Name: "hidden-cmd", Hidden: true},
+	})
+	app.Subcommands = []Namespace{
+		{Name: "users", Description: "Manage users.", Commands: []Command{{Name: "list", Description: "List the users."}}},
+		{Name: "internal", Hidden: true, Commands: []Command{{Name: "debug"}}},
+	}
+	return app
+}
+
+func TestGenerateDocs(t *testing.T) {
+	outputDir := t.TempDir()
+	assert.NoError(t, GenerateDocs(getDocsTestApp(), outputDir, ""))
+	for _, docsFile := range []string{"test-app.md", "man/test-app.1", "man/test-app-greet.1", "man/test-app-users-list.1"} {
+		expected, err := os.ReadFile(filepath.Join("testdata", "docs", filepath.FromSlash(docsFile)))
+		assert.NoError(t, err)
+		actual, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(docsFile)))
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual), docsFile)
+	}
+	entries, err := os.ReadDir(filepath.Join(outputDir, "man"))
+	assert.NoError(t, err)
+	// Hidden namespaces and commands have no man pages
+	assert.Len(t, entries, 3)
+}
+
+func TestGenerateDocsWithExecutableName(t *testing.T) {
+	markdown, err := GenerateMarkdownDocs(getDocsTestApp(), "jf", "test-app")
+	assert.NoError(t, err)
+	assert.Contains(t, markdown, "jf test-app greet [command options] --greeting=<text> <name> [title]")
+	manPages, err := GenerateManPages(getDocsTestApp(), "jf", "test-app")
+	assert.NoError(t, err)
+	assert.Contains(t, manPages["test-app.1"], `jf test\-app <command> [command options] [arguments...]`)
+	assert.Contains(t, manPages["test-app-greet.1"], `jf test\-app greet [command options] \-\-greeting=<text> <name> [title]`)
+}
+
+func TestEscapeRoff(t *testing.T) {
+	assert.Equal(t, `\-\-flag`, escapeRoff("--flag"))
+	assert.Equal(t, `C:\eplugins`, escapeRoff(`C:\plugins`))
+	assert.Equal(t, "first\n\\&.second\n\\&'third", escapeRoff("first\n.second\n'third"))
+}
@@ -0,0 +1,216 @@
+package components
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type FlagType string
+
+const (
+	StringFlagType      FlagType = "string"
+	BoolFlagType        FlagType = "bool"
+	IntFlagType         FlagType = "int"
+	DurationFlagType    FlagType = "duration"
+	StringSliceFlagType FlagType = "string-slice"
+	EnumFlagType        FlagType = "enum"
+)
+
+// Create the manifest of the app, which describes its commands, namespaces, flags and environment variables.
+// coreVersion is the version of jfrog-cli-core, which the plugin was built with.
+func CreatePluginManifest(app App, coreVersion string) PluginSignature {
+	manifest := PluginSignature{
+		Name:        app.Name,
+		Usage:       app.Description,
+		Version:     app.Version,
+		CoreVersion: coreVersion,
+		Commands:    createCommandManifests(app.Commands),
+	}
+	if app.CompatibleCoreVersions != (VersionRange{}) {
+		compatibleCoreVersions := app.CompatibleCoreVersions
+		manifest.CompatibleCoreVersions = &compatibleCoreVersions
+	}
+	for _, namespace := range app.Subcommands {
+		manifest.Namespaces = append(manifest.Namespaces, NamespaceManifest{
+			Name:        namespace.Name,
+			Description: namespace.Description,
+			Hidden:      namespace.Hidden,
+			Category:    namespace.Category,
+			Commands:    createCommandManifests(namespace.Commands),
+		})
+	}
+	return manifest
+}
+
+func createCommandManifests(commands []Command) (manifests []CommandManifest) {
+	for _, command := range commands {
+		commandManifest := CommandManifest{
+			Name:        command.Name,
+			Description: command.Description,
+			Category:    command.Category,
+			Aliases:     command.Aliases,
+			Hidden:      command.Hidden,
+		}
+		for _, argument := range command.Arguments {
+			commandManifest.Arguments = append(commandManifest.Arguments, ArgumentManifest{
+				Name:            argument.Name,
+				Description:     argument.Description,
+				Optional:        argument.Optional,
+				ReplaceWithFlag: argument.ReplaceWithFlag,
+			})
+		}
+		for _, flag := range command.Flags {
+			commandManifest.Flags = append(commandManifest.Flags, createFlagManifest(flag))
+		}
+		for _, envVar := range command.EnvVars {
+			commandManifest.EnvVars = append(commandManifest.EnvVars, EnvVarManifest(envVar))
+		}
+		manifests = append(manifests, commandManifest)
+	}
+	return
+}
+
+func createFlagManifest(flag Flag) FlagManifest {
+	flagManifest := FlagManifest{Name: flag.GetName(), Description: flag.GetDescription(), Mandatory: flag.IsMandatory()}
+	switch actualType := flag.(type) {
+	case StringFlag:
+		flagManifest.Type = StringFlagType
+		flagManifest.Hidden = actualType.Hidden
+		flagManifest.DefaultValue = actualType.DefaultValue
+	case BoolFlag:
+		flagManifest.Type = BoolFlagType
+		flagManifest.Hidden = actualType.Hidden
+		if actualType.DefaultValue {
+			flagManifest.DefaultValue = strconv.FormatBool(actualType.DefaultValue)
+		}
+	case IntFlag:
+		flagManifest.Type = IntFlagType
+		flagManifest.Hidden = actualType.Hidden
+		if actualType.HasDefaultValue {
+			flagManifest.DefaultValue = strconv.Itoa(actualType.DefaultValue)
+		}
+	case DurationFlag:
+		flagManifest.Type = DurationFlagType
+		flagManifest.Hidden = actualType.Hidden
+		if actualType.HasDefaultValue {
+			flagManifest.DefaultValue = actualType.DefaultValue.String()
+		}
+	case StringSliceFlag:
+		flagManifest.Type = StringSliceFlagType
+		flagManifest.Hidden = actualType.Hidden
+		flagManifest.DefaultValue = actualType.toStringFlag().DefaultValue
+	case EnumFlag:
+		flagManifest.Type = EnumFlagType
+		flagManifest.Hidden = actualType.Hidden
+		flagManifest.DefaultValue = actualType.DefaultValue
+		flagManifest.AllowedValues = actualType.AllowedValues
+	}
+	return flagManifest
+}
+
+// Read the manifest printed by the hidden signature command of a plugin.
+func ReadPluginManifest(content []byte) (*PluginSignature, error) {
+	manifest := new(PluginSignature)
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the plugin manifest: %s", err.Error())
+	}
+	return manifest, nil
+}
+
+// Validate the manifest before loading the plugin. Returns all the problems found in the manifest.
+func (ps *PluginSignature) Validate() error {
+	var errs []error
+	if ps.Name == "" {
+		errs = append(errs, errors.New("the plugin name is missing"))
+	}
+	if ps.CompatibleCoreVersions != nil {
+		versionRange := ps.CompatibleCoreVersions
+		if versionRange.Min != "" && versionRange.Max != "" && version.NewVersion(versionRange.Max).Compare(versionRange.Min) > 0 {
+			errs = append(errs, fmt.Errorf("invalid compatible jfrog-cli-core versions range: %s is greater than %s", versionRange.Min, versionRange.Max))
+		}
+	}
+	names := make(map[string]bool)
+	for _, namespace := range ps.Namespaces {
+		if names[namespace.Name] {
+			errs = append(errs, fmt.Errorf("the namespace '%s' is defined more than once", namespace.Name))
+		}
+		names[namespace.Name] = true
+		errs = append(errs, validateCommandManifests(namespace.Commands, nil, namespace.Name+" ")...)
+	}
+	errs = append(errs, validateCommandManifests(ps.Commands, names, "")...)
+	if len(errs) > 0 {
+		return errorutils.CheckErrorf("invalid manifest of the plugin '%s':\n%s", ps.Name, errors.Join(errs...).Error())
+	}
+	return nil
+}
+
+// Validate commands of the same level. names holds the names, which are already used in this level, such as namespaces.
+func validateCommandManifests(commands []CommandManifest, names map[string]bool, commandPrefix string) (errs []error) {
+	if names == nil {
+		names = make(map[string]bool)
+	}
+	for _, command := range commands {
+		if command.Name == "" {
+			errs = append(errs, fmt.Errorf("a command name is missing under '%s'", commandPrefix))
+			continue
+		}
+		for _, name := range append([]string{command.Name}, command.Aliases...) {
+			if names[name] {
+				errs = append(errs, fmt.Errorf("command '%s%s': the name '%s' is already used", commandPrefix, command.Name, name))
+			}
+			names[name] = true
+		}
+		flagNames := make(map[string]bool)
+		for _, flag := range command.Flags {
+			if flagNames[flag.Name] {
+				errs = append(errs, fmt.Errorf("command '%s%s': the flag '%s' is defined more than once", commandPrefix, command.Name, flag.Name))
+			}
+			flagNames[flag.Name] = true
+			if err := flag.validate(); err != nil {
+				errs = append(errs, fmt.Errorf("command '%s%s': %w", commandPrefix, command.Name, err))
+			}
+		}
+	}
+	return
+}
+
+func (fm *FlagManifest) validate() error {
+	switch fm.Type {
+	case StringFlagType, BoolFlagType, IntFlagType, DurationFlagType, StringSliceFlagType:
+	case EnumFlagType:
+		if len(fm.AllowedValues) == 0 {
+			return fmt.Errorf("the enum flag '%s' has no allowed values", fm.Name)
+		}
+		if fm.DefaultValue != "" && !slices.Contains(fm.AllowedValues, fm.DefaultValue) {
+			return fmt.Errorf("the default value '%s' of the flag '%s' is not one of its allowed values", fm.DefaultValue, fm.Name)
+		}
+	default:
+		return fmt.Errorf("the flag '%s' has an unknown type '%s'", fm.Name, fm.Type)
+	}
+	if fm.Mandatory && fm.DefaultValue != "" {
+		return fmt.Errorf("the mandatory flag '%s' has a default value", fm.Name)
+	}
+	return nil
+}
+
+// Check whether the plugin supports the jfrog-cli-core version of the hosting CLI.
+// Plugins, which don't declare the compatible versions, are considered compatible.
+func (ps *PluginSignature) CheckCoreCompatibility(hostCoreVersion string) error {
+	if ps.CompatibleCoreVersions == nil {
+		return nil
+	}
+	hostVersion := version.NewVersion(hostCoreVersion)
+	if minVersion := ps.CompatibleCoreVersions.Min; minVersion != "" && !hostVersion.AtLeast(minVersion) {
+		return errorutils.CheckErrorf("the plugin '%s' requires jfrog-cli-core %s or above, but the CLI uses version %s", ps.Name, minVersion, hostCoreVersion)
+	}
+	if maxVersion := ps.CompatibleCoreVersions.Max; maxVersion != "" && hostVersion.Compare(maxVersion) < 0 {
+		return errorutils.CheckErrorf("the plugin '%s' supports jfrog-cli-core up to version %s, but the CLI uses version %s", ps.Name, maxVersion, hostCoreVersion)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package components
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePluginManifest(t *testing.T) {
+	app := CreateEmbeddedApp("test-app", []Command{{
+		Name:        "run",
+		Description: "Run it.",
+		Aliases:     []string{"r"},
+		Arguments:   []Argument{{Name: "target", Optional: true, Description: "The target."}},
+		Flags: []Flag{
+			NewStringFlag("server-id", "Server ID.", WithStrDefaultValue("default")),
+			NewBoolFlag("dry-run", "Dry run.", WithBoolDefaultValueTrue()),
+			NewIntFlag("threads", "Threads.", WithIntFlagDefaultValue(3)),
+			NewDurationFlag("timeout", "Timeout.", WithDurationDefaultValue(time.Minute)),
+			NewStringSliceFlag("tag", "Tag.", WithStringSliceDefaultValue("a", "b")),
+			NewEnumFlag("format", "Format.", []string{"json", "table"}, SetMandatoryEnumFlag()),
+		},
+		EnvVars: []EnvVar{{Name: "TEST_ENV", Default: "x", Description: "Test env."}},
+	}}, Namespace{Name: "ns", Description: "A namespace.", Commands: []Command{{Name: "inner", Hidden: true}}})
+	app.Version = "1.2.3"
+	app.CompatibleCoreVersions = VersionRange{Min: "2.50.0"}
+
+	manifest := CreatePluginManifest(app, "2.60.0")
+	assert.Equal(t, "test-app", manifest.Name)
+	assert.Equal(t, "1.2.3", manifest.Version)
+	assert.Equal(t, "2.60.0", manifest.CoreVersion)
+	assert.Equal(t, &VersionRange{Min: "2.50.0"}, manifest.CompatibleCoreVersions)
+	if assert.Len(t, manifest.Commands, 1) {
+		command := manifest.Commands[0]
+		assert.Equal(t, []string{"r"}, command.Aliases)
+		assert.Equal(t, []ArgumentManifest{{Name: "target", Optional: true, Description: "The target."}}, command.Arguments)
+		assert.Equal(t, []FlagManifest{
+			{Name: "server-id", Type: StringFlagType, Description: "Server ID.", DefaultValue: "default"},
+			{Name: "dry-run", Type: BoolFlagType, Description: "Dry run.", DefaultValue: "true"},
+			{Name: "threads", Type: IntFlagType, Description: "Threads.", DefaultValue: "3"},
+			{Name: "timeout", Type: DurationFlagType, Description: "Timeout.", DefaultValue: "1m0s"},
+			{Name: "tag", Type: StringSliceFlagType, Description: "Tag.", DefaultValue: "a,b"},
+			{Name: "format", Type: EnumFlagType, Description: "Format.", Mandatory: true, AllowedValues: []string{"json", "table"}},
+		}, command.Flags)
+		assert.Equal(t, []EnvVarManifest{{Name: "TEST_ENV", Default: "x", Description: "Test env."}}, command.EnvVars)
+	}
+	assert.Equal(t, []NamespaceManifest{{Name: "ns", Description: "A namespace.", Commands: []CommandManifest{{Name: "inner", Hidden: true}}}}, manifest.Namespaces)
+	assert.NoError(t, manifest.Validate())
+
+	// The manifest is read back as written
+	content, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+	readManifest, err := ReadPluginManifest(content)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest, *readManifest)
+}
+
+func TestReadLegacyPluginSignature(t *testing.T) {
+	manifest, err := ReadPluginManifest([]byte(`{"name": "legacy", "usage": "A legacy plugin."}`))
+	assert.NoError(t, err)
+	assert.Equal(t, PluginSignature{Name: "legacy", Usage: "A legacy plugin."}, *manifest)
+	assert.NoError(t, manifest.Validate())
+	assert.NoError(t, manifest.CheckCoreCompatibility("2.0.0"))
+
+	_, err = ReadPluginManifest([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestValidatePluginManifest(t *testing.T) {
+	manifest := PluginSignature{
+		Name:                   "test-app",
+		CompatibleCoreVersions: &VersionRange{Min: "2.10.0", Max: "2.9.0"},
+		Namespaces:             []NamespaceManifest{{Name: "ns"}},
+		Commands: []CommandManifest{
+			{Name: "ns"},
+			{Name: "run", Aliases: []string{"r"}, Flags: []FlagManifest{
+				{Name: "format", Type: EnumFlagType, AllowedValues: []string{"json"}, DefaultValue: "xml"},
+				{Name: "format", Type: StringFlagType},
+				{Name: "size", Type: "float"},
+				{Name: "url", Type: StringFlagType, Mandatory: true, DefaultValue: "x"},
+			}},
+			{Name: "remove", Aliases: []string{"r"}},
+		},
+	}
+	err := manifest.Validate()
+	if assert.Error(t, err) {
+		for _, expected := range []string{
+			"2.10.0 is greater than 2.9.0",
+			"command 'ns': the name 'ns' is already used",
+			"the default value 'xml' of the flag 'format' is not one of its allowed values",
+			"command 'run': the flag 'format' is defined more than once",
+			"the flag 'size' has an unknown type 'float'",
+			"the mandatory flag 'url' has a default value",
+			"command 'remove': the name 'r' is already used",
+		} {
+			assert.Contains(t, err.Error(), expected)
+		}
+	}
+}
+
+func TestCheckCoreCompatibility(t *testing.T) {
+	manifest := PluginSignature{Name: "test-app", CompatibleCoreVersions: &VersionRange{Min: "2.50.0", Max: "2.60.0"}}
+	assert.NoError(t, manifest.CheckCoreCompatibility("2.50.0"))
+	assert.NoError(t, manifest.CheckCoreCompatibility("2.55.1"))
+	assert.NoError(t, manifest.CheckCoreCompatibility("2.60.0"))
+	assert.ErrorContains(t, manifest.CheckCoreCompatibility("2.49.9"), "requires jfrog-cli-core 2.50.0 or above")
+	assert.ErrorContains(t, manifest.CheckCoreCompatibility("2.61.0"), "supports jfrog-cli-core up to version 2.60.0")
+}
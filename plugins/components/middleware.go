@@ -0,0 +1,73 @@
+package components
+
+// Logic, which runs around the actions of commands, such as resolving the server details or printing the command summary.
+// Middlewares are defined on the app, namespaces and commands. The middlewares of a command run in this order:
+//  1. The Before functions of the app, the namespace and the command middlewares, in their definition order.
+//  2. The command action.
+//  3. The After functions, or the OnError functions if an error occurred, in reverse order.
+//
+// If a Before function fails, the action doesn't run, and only the middlewares before it handle the error.
+// All the functions are optional.
+type Middleware struct {
+	Before func(c *Context) error
+	// Runs if no error occurred so far.
+	After func(c *Context) error
+	// Runs if an error occurred. The returned error replaces the original error, and nil clears it.
+	OnError func(c *Context, err error) error
+}
+
+// Run the action of the command with its middlewares.
+func runWithMiddlewares(c *Context, middlewares []Middleware, action ActionFunc) (err error) {
+	started := 0
+	for _, middleware := range middlewares {
+		if middleware.Before != nil {
+			if err = middleware.Before(c); err != nil {
+				break
+			}
+		}
+		started++
+	}
+	if err == nil {
+		err = action(c)
+	}
+	for i := started - 1; i >= 0; i-- {
+		middleware := middlewares[i]
+		switch {
+		case err == nil && middleware.After != nil:
+			err = middleware.After(c)
+		case err != nil && middleware.OnError != nil:
+			err = middleware.OnError(c, err)
+		}
+	}
+	return
+}
+
+// Returns the commands with the middlewares of their parents, before their own middlewares.
+// Internal commands keep only their own middlewares.
+func withParentMiddlewares(commands []Command, parentMiddlewares []Middleware) []Command {
+	if len(parentMiddlewares) == 0 {
+		return commands
+	}
+	result := make([]Command, 0, len(commands))
+	for _, command := range commands {
+		if command.internal {
+			result = append(result, command)
+			continue
+		}
+		command.Middlewares = append(append([]Middleware{}, parentMiddlewares...), command.Middlewares...)
+		result = append(result, command)
+	}
+	return result
+}
+
+// Set a value, which is shared between the middlewares and the action of the command e.g. the resolved server details.
+func (c *Context) SetValue(key string, value any) {
+	if c.values == nil {
+		c.values = make(map[string]any)
+	}
+	c.values[key] = value
+}
+
+func (c *Context) GetValue(key string) any {
+	return c.values[key]
+}
@@ -0,0 +1,102 @@
+package components
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// A middleware, which records its calls.
+func getRecordingMiddleware(name string, calls *[]string, beforeErr error) Middleware {
+	return Middleware{
+		Before: func(c *Context) error {
+			*calls = append(*calls, name+" before")
+			return beforeErr
+		},
+		After: func(c *Context) error {
+			*calls = append(*calls, name+" after")
+			return nil
+		},
+		OnError: func(c *Context, err error) error {
+			*calls = append(*calls, name+" on error: "+err.Error())
+			return err
+		},
+	}
+}
+
+func TestMiddlewaresOrder(t *testing.T) {
+	var calls []string
+	action := func(c *Context) error {
+		calls = append(calls, "action")
+		return nil
+	}
+	app := CreateApp("test-app", "1.0.0", "", []Command{{
+		Name:        "cmd",
+		Action:      action,
+		Middlewares: []Middleware{getRecordingMiddleware("cmd", &calls, nil)},
+	}})
+	app.Middlewares = []Middleware{getRecordingMiddleware("app", &calls, nil)}
+	app.Subcommands = []Namespace{{
+		Name:        "ns",
+		Middlewares: []Middleware{getRecordingMiddleware("ns", &calls, nil)},
+		Commands:    []Command{{Name: "ns-cmd", Action: action, Middlewares: []Middleware{getRecordingMiddleware("ns-cmd", &calls, nil)}}},
+	}}
+	cliApp, err := ConvertApp(app)
+	assert.NoError(t, err)
+
+	assert.NoError(t, cliApp.Run([]string{"test-app", "cmd"}))
+	assert.Equal(t, []string{"app before", "cmd before", "action", "cmd after", "app after"}, calls)
+
+	calls = nil
+	assert.NoError(t, cliApp.Run([]string{"test-app", "ns", "ns-cmd"}))
+	assert.Equal(t, []string{"app before", "ns before", "ns-cmd before", "action", "ns-cmd after", "ns after", "app after"}, calls)
+	// The middlewares of the app commands are not changed by the conversion
+	assert.Len(t, app.Commands[0].Middlewares, 1)
+}
+
+func TestMiddlewaresErrors(t *testing.T) {
+	var calls []string
+	actionErr := errors.New("action failed")
+	actionCalled := false
+	action := func(c *Context) error {
+		actionCalled = true
+		return actionErr
+	}
+
+	// The action fails - the OnError functions are called in reverse order
+	err := runWithMiddlewares(&Context{}, []Middleware{getRecordingMiddleware("first", &calls, nil), getRecordingMiddleware("second", &calls, nil)}, action)
+	assert.ErrorIs(t, err, actionErr)
+	assert.True(t, actionCalled)
+	assert.Equal(t, []string{"first before", "second before", "second on error: action failed", "first on error: action failed"}, calls)
+
+	// A Before function fails - the action doesn't run, and only the previous middlewares handle the error
+	calls, actionCalled = nil, false
+	beforeErr := errors.New("before failed")
+	err = runWithMiddlewares(&Context{}, []Middleware{getRecordingMiddleware("first", &calls, nil), getRecordingMiddleware("second", &calls, beforeErr)}, action)
+	assert.ErrorIs(t, err, beforeErr)
+	assert.False(t, actionCalled)
+	assert.Equal(t, []string{"first before", "second before", "first on error: before failed"}, calls)
+
+	// An OnError function clears the error - the previous middlewares run their After functions
+	calls = nil
+	clearingMiddleware := Middleware{OnError: func(c *Context, err error) error { return nil }}
+	err = runWithMiddlewares(&Context{}, []Middleware{getRecordingMiddleware("first", &calls, nil), clearingMiddleware}, action)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first before", "first after"}, calls)
+}
+
+func TestContextValues(t *testing.T) {
+	middleware := Middleware{Before: func(c *Context) error {
+		c.SetValue("key", "value")
+		return nil
+	}}
+	var value any
+	err := runWithMiddlewares(&Context{}, []Middleware{middleware}, func(c *Context) error {
+		value = c.GetValue("key")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+	assert.Nil(t, (&Context{}).GetValue("key"))
+}
@@ -4,6 +4,16 @@ type App struct {
 	Version string
 	Namespace
 	Subcommands []Namespace
+	// Optional. The versions of jfrog-cli-core of the hosting CLI, which the plugin supports.
+	CompatibleCoreVersions VersionRange
+}
+
+// A range of versions. Empty bounds are unlimited.
+type VersionRange struct {
+	// The minimal supported version, inclusive.
+	Min string `json:"min,omitempty"`
+	// The maximal supported version, inclusive.
+	Max string `json:"max,omitempty"`
 }
 
 func CreateApp(name, version, description string, commands []Command) App {
@@ -34,6 +44,8 @@ type Namespace struct {
 	Hidden      bool
 	Category    string
 	Commands    []Command
+	// Run around the actions of all the commands of the namespace, or of the app, after the middlewares of their parents.
+	Middlewares []Middleware
 }
 
 type Command struct {
@@ -48,6 +60,10 @@ type Command struct {
 	Action          ActionFunc
 	SkipFlagParsing bool
 	Hidden          bool
+	// Run around the action of the command, after the middlewares of the app and the namespace.
+	Middlewares []Middleware
+	// Internal commands, such as the completion commands, don't run the middlewares of the app and the namespace.
+	internal bool
 }
 
 type UsageOptions struct {
@@ -57,9 +73,61 @@ type UsageOptions struct {
 	ReplaceAutoGeneratedUsage bool
 }
 
+// The manifest of a plugin, which the plugin prints when the CLI runs its hidden signature command.
+// Hosts, which only read the name and usage, remain compatible with the manifest.
 type PluginSignature struct {
 	Name  string `json:"name,omitempty"`
 	Usage string `json:"usage,omitempty"`
 	// Only used internally in the CLI.
 	ExecutablePath string `json:"executablePath,omitempty"`
+	Version        string `json:"version,omitempty"`
+	// The version of jfrog-cli-core, which the plugin was built with.
+	CoreVersion            string              `json:"coreVersion,omitempty"`
+	CompatibleCoreVersions *VersionRange       `json:"compatibleCoreVersions,omitempty"`
+	Commands               []CommandManifest   `json:"commands,omitempty"`
+	Namespaces             []NamespaceManifest `json:"namespaces,omitempty"`
+}
+
+type NamespaceManifest struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Hidden      bool              `json:"hidden,omitempty"`
+	Category    string            `json:"category,omitempty"`
+	Commands    []CommandManifest `json:"commands,omitempty"`
+}
+
+type CommandManifest struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Category    string             `json:"category,omitempty"`
+	Aliases     []string           `json:"aliases,omitempty"`
+	Hidden      bool               `json:"hidden,omitempty"`
+	Arguments   []ArgumentManifest `json:"arguments,omitempty"`
+	Flags       []FlagManifest     `json:"flags,omitempty"`
+	EnvVars     []EnvVarManifest   `json:"envVars,omitempty"`
+}
+
+type ArgumentManifest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description,omitempty"`
+	Optional        bool   `json:"optional,omitempty"`
+	ReplaceWithFlag string `json:"replaceWithFlag,omitempty"`
+}
+
+type FlagManifest struct {
+	Name        string   `json:"name"`
+	Type        FlagType `json:"type"`
+	Description string   `json:"description,omitempty"`
+	Mandatory   bool     `json:"mandatory,omitempty"`
+	Hidden      bool     `json:"hidden,omitempty"`
+	// The default value, formatted as it would be provided in the command line.
+	DefaultValue string `json:"defaultValue,omitempty"`
+	// The allowed values of enum flags.
+	AllowedValues []string `json:"allowedValues,omitempty"`
+}
+
+type EnvVarManifest struct {
+	Name        string `json:"name"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
 }
@@ -0,0 +1,292 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type IntFlag struct {
+	BaseFlag
+	Mandatory bool
+	// A flag with default value cannot be mandatory.
+	DefaultValue    int
+	HasDefaultValue bool
+	// Optional. If set, values outside the range are rejected.
+	MinValue *int
+	MaxValue *int
+}
+
+type IntFlagOption func(f *IntFlag)
+
+func NewIntFlag(name, description string, options ...IntFlagOption) IntFlag {
+	f := IntFlag{BaseFlag: NewFlag(name, description)}
+	for _, option := range options {
+		option(&f)
+	}
+	return f
+}
+
+func (f IntFlag) IsMandatory() bool {
+	return f.Mandatory
+}
+
+func WithIntFlagDefaultValue(defaultValue int) IntFlagOption {
+	return func(f *IntFlag) {
+		f.DefaultValue = defaultValue
+		f.HasDefaultValue = true
+	}
+}
+
+func WithIntRange(minValue, maxValue int) IntFlagOption {
+	return func(f *IntFlag) {
+		f.MinValue = &minValue
+		f.MaxValue = &maxValue
+	}
+}
+
+func WithIntMinValue(minValue int) IntFlagOption {
+	return func(f *IntFlag) {
+		f.MinValue = &minValue
+	}
+}
+
+func WithIntMaxValue(maxValue int) IntFlagOption {
+	return func(f *IntFlag) {
+		f.MaxValue = &maxValue
+	}
+}
+
+func SetMandatoryIntFlag() IntFlagOption {
+	return func(f *IntFlag) {
+		f.Mandatory = true
+	}
+}
+
+func SetHiddenIntFlag() IntFlagOption {
+	return func(f *IntFlag) {
+		f.Hidden = true
+	}
+}
+
+func (f IntFlag) toStringFlag() StringFlag {
+	stringFlag := StringFlag{BaseFlag: f.BaseFlag, Mandatory: f.Mandatory, HelpValue: "int"}
+	if f.HasDefaultValue {
+		stringFlag.DefaultValue = strconv.Itoa(f.DefaultValue)
+	}
+	switch {
+	case f.MinValue != nil && f.MaxValue != nil:
+		stringFlag.Description = fmt.Sprintf("%s Allowed range: %d-%d.", stringFlag.Description, *f.MinValue, *f.MaxValue)
+	case f.MinValue != nil:
+		stringFlag.Description = fmt.Sprintf("%s Minimum value: %d.", stringFlag.Description, *f.MinValue)
+	case f.MaxValue != nil:
+		stringFlag.Description = fmt.Sprintf("%s Maximum value: %d.", stringFlag.Description, *f.MaxValue)
+	}
+	return stringFlag
+}
+
+func (f IntFlag) validate(value string) error {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid value '%s' for the int flag '%s'", value, f.Name)
+	}
+	if f.MinValue != nil && parsed < *f.MinValue {
+		return fmt.Errorf("the value of the flag '%s' must be at least %d, but got %d", f.Name, *f.MinValue, parsed)
+	}
+	if f.MaxValue != nil && parsed > *f.MaxValue {
+		return fmt.Errorf("the value of the flag '%s' must be at most %d, but got %d", f.Name, *f.MaxValue, parsed)
+	}
+	return nil
+}
+
+// A duration flag, such as 1h30m or 90s.
+type DurationFlag struct {
+	BaseFlag
+	Mandatory bool
+	// A flag with default value cannot be mandatory.
+	DefaultValue    time.Duration
+	HasDefaultValue bool
+}
+
+type DurationFlagOption func(f *DurationFlag)
+
+func NewDurationFlag(name, description string, options ...DurationFlagOption) DurationFlag {
+	f := DurationFlag{BaseFlag: NewFlag(name, description)}
+	for _, option := range options {
+		option(&f)
+	}
+	return f
+}
+
+func (f DurationFlag) IsMandatory() bool {
+	return f.Mandatory
+}
+
+func WithDurationDefaultValue(defaultValue time.Duration) DurationFlagOption {
+	return func(f *DurationFlag) {
+		f.DefaultValue = defaultValue
+		f.HasDefaultValue = true
+	}
+}
+
+func SetMandatoryDurationFlag() DurationFlagOption {
+	return func(f *DurationFlag) {
+		f.Mandatory = true
+	}
+}
+
+func SetHiddenDurationFlag() DurationFlagOption {
+	return func(f *DurationFlag) {
+		f.Hidden = true
+	}
+}
+
+func (f DurationFlag) toStringFlag() StringFlag {
+	stringFlag := StringFlag{BaseFlag: f.BaseFlag, Mandatory: f.Mandatory, HelpValue: "duration"}
+	if f.HasDefaultValue {
+		stringFlag.DefaultValue = f.DefaultValue.String()
+	}
+	return stringFlag
+}
+
+func (f DurationFlag) validate(value string) error {
+	if _, err := time.ParseDuration(value); err != nil {
+		return fmt.Errorf("invalid value '%s' for the duration flag '%s'. Expected a duration such as 30s, 5m or 1h30m", value, f.Name)
+	}
+	return nil
+}
+
+// A flag, which can be provided multiple times e.g. --tag=a --tag=b
+type StringSliceFlag struct {
+	BaseFlag
+	Mandatory bool
+	// A flag with default value cannot be mandatory.
+	DefaultValue []string
+}
+
+type StringSliceFlagOption func(f *StringSliceFlag)
+
+func NewStringSliceFlag(name, description string, options ...StringSliceFlagOption) StringSliceFlag {
+	f := StringSliceFlag{BaseFlag: NewFlag(name, description)}
+	for _, option := range options {
+		option(&f)
+	}
+	return f
+}
+
+func (f StringSliceFlag) IsMandatory() bool {
+	return f.Mandatory
+}
+
+func WithStringSliceDefaultValue(defaultValue ...string) StringSliceFlagOption {
+	return func(f *StringSliceFlag) {
+		f.DefaultValue = defaultValue
+	}
+}
+
+func SetMandatoryStringSliceFlag() StringSliceFlagOption {
+	return func(f *StringSliceFlag) {
+		f.Mandatory = true
+	}
+}
+
+func SetHiddenStringSliceFlag() StringSliceFlagOption {
+	return func(f *StringSliceFlag) {
+		f.Hidden = true
+	}
+}
+
+func (f StringSliceFlag) toStringFlag() StringFlag {
+	stringFlag := StringFlag{BaseFlag: f.BaseFlag, Mandatory: f.Mandatory, DefaultValue: strings.Join(f.DefaultValue, ",")}
+	stringFlag.Description += " Can be provided multiple times."
+	return stringFlag
+}
+
+// A string flag, which accepts only a set of allowed values.
+type EnumFlag struct {
+	BaseFlag
+	AllowedValues []string
+	Mandatory     bool
+	// A flag with default value cannot be mandatory.
+	DefaultValue string
+}
+
+type EnumFlagOption func(f *EnumFlag)
+
+func NewEnumFlag(name, description string, allowedValues []string, options ...EnumFlagOption) EnumFlag {
+	f := EnumFlag{BaseFlag: NewFlag(name, description), AllowedValues: allowedValues}
+	for _, option := range options {
+		option(&f)
+	}
+	return f
+}
+
+func (f EnumFlag) IsMandatory() bool {
+	return f.Mandatory
+}
+
+func WithEnumDefaultValue(defaultValue string) EnumFlagOption {
+	return func(f *EnumFlag) {
+		f.DefaultValue = defaultValue
+	}
+}
+
+func SetMandatoryEnumFlag() EnumFlagOption {
+	return func(f *EnumFlag) {
+		f.Mandatory = true
+	}
+}
+
+func SetHiddenEnumFlag() EnumFlagOption {
+	return func(f *EnumFlag) {
+		f.Hidden = true
+	}
+}
+
+func (f EnumFlag) toStringFlag() StringFlag {
+	stringFlag := StringFlag{BaseFlag: f.BaseFlag, Mandatory: f.Mandatory, DefaultValue: f.DefaultValue, HelpValue: strings.Join(f.AllowedValues, "|")}
+	stringFlag.Description = fmt.Sprintf("%s Allowed values: %s.", stringFlag.Description, strings.Join(f.AllowedValues, ", "))
+	return stringFlag
+}
+
+func (f EnumFlag) validate(value string) error {
+	if !slices.Contains(f.AllowedValues, value) {
+		return fmt.Errorf("invalid value '%s' for the flag '%s'. Allowed values: %s", value, f.Name, strings.Join(f.AllowedValues, ", "))
+	}
+	return nil
+}
+
+// Typed flags, which are passed as strings and validated when the command runs.
+type validatedStringFlag interface {
+	Flag
+	toStringFlag() StringFlag
+	validate(value string) error
+}
+
+func (c *Context) GetDurationFlagValue(flagName string) (value time.Duration, err error) {
+	value, err = time.ParseDuration(c.GetStringFlagValue(flagName))
+	if err != nil {
+		err = fmt.Errorf("can't parse duration flag '%s': %w", flagName, err)
+	}
+	return
+}
+
+func (c *Context) GetDefaultDurationFlagValueIfNotSet(flagName string, defaultValue time.Duration) (time.Duration, error) {
+	if c.IsFlagSet(flagName) {
+		return c.GetDurationFlagValue(flagName)
+	}
+	return defaultValue, nil
+}
+
+func (c *Context) GetStringSliceFlagValue(flagName string) []string {
+	return c.stringSliceFlags[flagName]
+}
+
+func (c *Context) AddStringSliceFlag(key string, value []string) {
+	if c.stringSliceFlags == nil {
+		c.stringSliceFlags = make(map[string][]string)
+	}
+	c.stringSliceFlags[key] = value
+}
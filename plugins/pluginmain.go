@@ -59,14 +59,10 @@ func RunCliWithPlugin(jfrogApp components.App) func() error {
 		// Set the plugin's user-agent as the jfrog-cli-core's.
 		utils.SetUserAgent(jfrogclicore.GetUserAgent())
 
-		cli.CommandHelpTemplate = commandHelpTemplate
-		cli.AppHelpTemplate = appHelpTemplate
-
-		baseApp, err := components.ConvertApp(jfrogApp)
+		baseApp, err := ConvertPluginApp(jfrogApp)
 		if err != nil {
 			coreutils.ExitOnErr(err)
 		}
-		addHiddenPluginSignatureCommand(baseApp)
 
 		args := os.Args
 		err = baseApp.Run(args)
@@ -78,3 +74,16 @@ func RunCliWithPlugin(jfrogApp components.App) func() error {
 		return err
 	}
 }
+
+// Convert the plugin app into a cli.App, with the plugin help templates and the hidden signature command.
+func ConvertPluginApp(jfrogApp components.App) (*cli.App, error) {
+	cli.CommandHelpTemplate = commandHelpTemplate
+	cli.AppHelpTemplate = appHelpTemplate
+
+	baseApp, err := components.ConvertApp(jfrogApp)
+	if err != nil {
+		return nil, err
+	}
+	addHiddenPluginSignatureCommand(baseApp, jfrogApp)
+	return baseApp, nil
+}
@@ -0,0 +1,213 @@
+package testkit
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	corelog "github.com/jfrog/jfrog-cli-core/v2/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+// Set this environment variable to true to update the golden files, instead of comparing against them.
+const UpdateGoldenFilesEnv = "JFROG_CLI_UPDATE_GOLDEN_FILES"
+
+// The name of the app, which wraps a single command tested by NewCommandRunner.
+const testAppName = "test-plugin"
+
+// Runs the commands of a plugin in-process, as the plugin executable would run them.
+// Each run is isolated - it uses a temporary JFrog home directory, with the configured servers, and the configured environment variables.
+type CommandRunner struct {
+	t             *testing.T
+	app           components.App
+	env           map[string]string
+	serverDetails []*config.ServerDetails
+}
+
+// The outputs of a command run.
+type CommandResult struct {
+	// The standard output, including the output printed with log.Output.
+	Stdout string
+	Stderr string
+	// The log messages, such as log.Info and log.Error messages.
+	Log      string
+	ExitCode int
+	Err      error
+}
+
+// Create a runner for the commands of the app. The arguments of Run start with the command name, without the app name.
+func NewAppRunner(t *testing.T, app components.App) *CommandRunner {
+	return &CommandRunner{t: t, app: app, env: make(map[string]string)}
+}
+
+// Create a runner for a single command. The arguments of Run start with the command name.
+func NewCommandRunner(t *testing.T, command components.Command) *CommandRunner {
+	return NewAppRunner(t, components.CreateApp(testAppName, "1.0.0", "", []components.Command{command}))
+}
+
+func (cr *CommandRunner) SetEnv(key, value string) *CommandRunner {
+	cr.env[key] = value
+	return cr
+}
+
+// Configure the servers of the JFrog home directory, which is used by the runs.
+// The first server is the default server, unless another server is marked as default.
+func (cr *CommandRunner) SetServerDetails(serverDetails ...*config.ServerDetails) *CommandRunner {
+	cr.serverDetails = serverDetails
+	return cr
+}
+
+// Run the command with the given arguments and flags e.g. Run("greet", "--name=world", "arg").
+func (cr *CommandRunner) Run(args ...string) *CommandResult {
+	cr.t.Helper()
+	cr.prepareEnvironment()
+
+	baseApp, err := plugins.ConvertPluginApp(cr.app)
+	if !assert.NoError(cr.t, err) {
+		return &CommandResult{Err: err, ExitCode: coreutils.ExitCodeError.Code}
+	}
+	// The streams, the logger and the exiter are restored in defers, so that a panicking command doesn't leave them replaced.
+	stdout, err := startCapture(&os.Stdout)
+	if !assert.NoError(cr.t, err) {
+		return &CommandResult{Err: err, ExitCode: coreutils.ExitCodeError.Code}
+	}
+	defer stdout.stop()
+	stderr, err := startCapture(&os.Stderr)
+	if !assert.NoError(cr.t, err) {
+		return &CommandResult{Err: err, ExitCode: coreutils.ExitCodeError.Code}
+	}
+	defer stderr.stop()
+	baseApp.Writer, baseApp.ErrWriter = os.Stdout, os.Stderr
+
+	logBuffer := &bytes.Buffer{}
+	previousLog := log.Logger
+	testLog := log.NewLogger(corelog.GetCliLogLevel(), nil)
+	testLog.SetOutputWriter(os.Stdout)
+	testLog.SetLogsWriter(logBuffer, 0)
+	log.SetLogger(testLog)
+	defer log.SetLogger(previousLog)
+	// Errors, which implement cli.ExitCoder, must not exit the test process.
+	previousExiter := cli.OsExiter
+	cli.OsExiter = func(int) {}
+	defer func() {
+		cli.OsExiter = previousExiter
+	}()
+
+	runErr := baseApp.Run(append([]string{cr.app.Name}, args...))
+
+	result := &CommandResult{
+		Stdout:   stdout.stop(),
+		Stderr:   stderr.stop(),
+		Log:      logBuffer.String(),
+		ExitCode: getExitCode(runErr),
+		Err:      runErr,
+	}
+	return result
+}
+
+// Create a temporary JFrog home directory, with the configured servers, and set the configured environment variables.
+// The environment is restored when the test ends.
+func (cr *CommandRunner) prepareEnvironment() {
+	cr.t.Helper()
+	cr.t.Setenv(coreutils.HomeDir, cr.t.TempDir())
+	for key, value := range cr.env {
+		cr.t.Setenv(key, value)
+	}
+	if len(cr.serverDetails) == 0 {
+		return
+	}
+	// The server details are copied, to avoid modifying the server details of the caller
+	serversDetails := make([]*config.ServerDetails, len(cr.serverDetails))
+	hasDefault := false
+	for i, serverDetails := range cr.serverDetails {
+		serverDetailsCopy := *serverDetails
+		serversDetails[i] = &serverDetailsCopy
+		hasDefault = hasDefault || serverDetails.IsDefault
+	}
+	if !hasDefault {
+		serversDetails[0].IsDefault = true
+	}
+	assert.NoError(cr.t, config.SaveServersConf(serversDetails))
+}
+
+// The exit code, which the plugin executable exits with, as determined by coreutils.ExitOnErr.
+func getExitCode(err error) int {
+	var cliError coreutils.CliError
+	if errors.As(err, &cliError) {
+		return cliError.Code
+	}
+	return coreutils.GetExitCode(err, 0, 0, false).Code
+}
+
+// Captures the content written to a standard stream, such as os.Stdout.
+type capture struct {
+	stream   **os.File
+	previous *os.File
+	writer   *os.File
+	content  bytes.Buffer
+	done     chan struct{}
+	stopped  bool
+}
+
+func startCapture(stream **os.File) (*capture, error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	c := &capture{stream: stream, previous: *stream, writer: writer, done: make(chan struct{})}
+	*stream = writer
+	// The pipe is read while the command runs, so that large outputs don't block the command
+	go func() {
+		_, _ = io.Copy(&c.content, reader)
+		_ = reader.Close()
+		close(c.done)
+	}()
+	return c, nil
+}
+
+// Restore the stream and return the captured content. Stopping an already stopped capture returns the same content.
+func (c *capture) stop() string {
+	if c.stopped {
+		return c.content.String()
+	}
+	c.stopped = true
+	*c.stream = c.previous
+	_ = c.writer.Close()
+	<-c.done
+	return c.content.String()
+}
+
+// Assert that the help of a command matches the golden file.
+// If the UpdateGoldenFilesEnv environment variable is set to true, the golden file is updated instead.
+func (cr *CommandRunner) AssertHelp(goldenFilePath string, commandPath ...string) {
+	cr.t.Helper()
+	result := cr.Run(append(commandPath, "--help")...)
+	assert.NoError(cr.t, result.Err)
+	AssertGolden(cr.t, goldenFilePath, result.Stdout)
+}
+
+// Assert that the actual content matches the golden file.
+// If the UpdateGoldenFilesEnv environment variable is set to true, the golden file is updated instead.
+func AssertGolden(t *testing.T, goldenFilePath, actual string) {
+	t.Helper()
+	if update, _ := strconv.ParseBool(os.Getenv(UpdateGoldenFilesEnv)); update {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(goldenFilePath), 0755))
+		assert.NoError(t, os.WriteFile(goldenFilePath, []byte(actual), 0644))
+		return
+	}
+	expected, err := os.ReadFile(goldenFilePath)
+	if !assert.NoError(t, err, "failed to read the golden file. Run the test with %s=true to create it", UpdateGoldenFilesEnv) {
+		return
+	}
+	assert.Equal(t, string(expected), actual, "the output doesn't match the golden file %s", goldenFilePath)
+}
@@ -0,0 +1,127 @@
+package testkit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+func getGreetCommand() components.Command {
+	return components.Command{
+		Name:        "greet",
+		Description: "Greet the user.",
+		Arguments:   []components.Argument{{Name: "name", Description: "The name of the user."}},
+		Flags: []components.Flag{
+			components.NewBoolFlag("shout", "Greet loudly."),
+			components.NewStringFlag("greeting", "The greeting.", components.WithStrDefaultValue("Hello")),
+		},
+		EnvVars: []components.EnvVar{{Name: "GREET_SUFFIX", Description: "Appended to the greeting."}},
+		Action: func(c *components.Context) error {
+			if len(c.Arguments) != 1 {
+				return coreutils.CliError{ExitCode: coreutils.ExitCodeFailNoOp, ErrorMsg: "expected a single name"}
+			}
+			greeting := fmt.Sprintf("%s %s%s", c.GetStringFlagValue("greeting"), c.Arguments[0], os.Getenv("GREET_SUFFIX"))
+			if c.GetBoolFlagValue("shout") {
+				log.Warn("Shouting")
+			}
+			fmt.Println(greeting)
+			_, err := fmt.Fprintln(os.Stderr, "greeted")
+			return err
+		},
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	result := NewCommandRunner(t, getGreetCommand()).SetEnv("GREET_SUFFIX", "!").Run("greet", "--shout", "--greeting=Hi", "world")
+	assert.NoError(t, result.Err)
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Equal(t, "Hi world!\n", result.Stdout)
+	assert.Equal(t, "greeted\n", result.Stderr)
+	assert.Contains(t, result.Log, "Shouting")
+	_, exists := os.LookupEnv("GREET_SUFFIX")
+	assert.True(t, exists, "the environment variables are set until the test ends")
+}
+
+func TestRunCommandExitCode(t *testing.T) {
+	result := NewCommandRunner(t, getGreetCommand()).Run("greet")
+	assert.EqualError(t, result.Err, "expected a single name")
+	assert.Equal(t, coreutils.ExitCodeFailNoOp.Code, result.ExitCode)
+
+	result = NewCommandRunner(t, getGreetCommand()).Run("greet", "--greeting")
+	assert.Error(t, result.Err)
+	assert.Equal(t, coreutils.ExitCodeError.Code, result.ExitCode)
+}
+
+func TestRunCommandWithServerDetails(t *testing.T) {
+	command := components.Command{
+		Name:  "server",
+		Flags: []components.Flag{common.GetServerIdFlag()},
+		Action: func(c *components.Context) error {
+			serverDetails, err := common.GetServerDetails(c)
+			if err != nil {
+				return err
+			}
+			log.Output(serverDetails.ServerId, serverDetails.ArtifactoryUrl)
+			return nil
+		},
+	}
+	firstServer := &config.ServerDetails{ServerId: "first", Url: "https://first.jfrog.io/", ArtifactoryUrl: "https://first.jfrog.io/artifactory/"}
+	runner := NewCommandRunner(t, command).SetServerDetails(
+		firstServer,
+		&config.ServerDetails{ServerId: "second", Url: "https://second.jfrog.io/", ArtifactoryUrl: "https://second.jfrog.io/artifactory/"},
+	)
+	result := runner.Run("server")
+	assert.False(t, firstServer.IsDefault, "the server details of the caller must not be modified")
+	assert.NoError(t, result.Err)
+	assert.Equal(t, "first https://first.jfrog.io/artifactory/\n", result.Stdout)
+
+	result = runner.Run("server", "--server-id=second")
+	assert.NoError(t, result.Err)
+	assert.Equal(t, "second https://second.jfrog.io/artifactory/\n", result.Stdout)
+
+	// Every run uses a new JFrog home directory
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	assert.NoError(t, err)
+	assert.DirExists(t, homeDir)
+}
+
+func TestRunPanickingCommand(t *testing.T) {
+	command := components.Command{
+		Name: "panic",
+		Action: func(c *components.Context) error {
+			panic("unexpected")
+		},
+	}
+	stdout, stderr, logger, exiter := os.Stdout, os.Stderr, log.Logger, reflect.ValueOf(cli.OsExiter).Pointer()
+	assert.PanicsWithValue(t, "unexpected", func() {
+		NewCommandRunner(t, command).Run("panic")
+	})
+	assert.Same(t, stdout, os.Stdout)
+	assert.Same(t, stderr, os.Stderr)
+	assert.Equal(t, logger, log.Logger)
+	assert.Equal(t, exiter, reflect.ValueOf(cli.OsExiter).Pointer())
+}
+
+func TestAssertHelp(t *testing.T) {
+	app := components.CreateApp("test-plugin", "1.0.0", "A test plugin.", []components.Command{getGreetCommand()})
+	NewAppRunner(t, app).AssertHelp(filepath.Join("testdata", "greet-help.txt"), "greet")
+}
+
+func TestAssertGoldenUpdate(t *testing.T) {
+	goldenFilePath := filepath.Join(t.TempDir(), "nested", "golden.txt")
+	t.Setenv(UpdateGoldenFilesEnv, "true")
+	AssertGolden(t, goldenFilePath, "content")
+	content, err := os.ReadFile(goldenFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
@@ -46,9 +46,16 @@ const (
 	DependenciesDir         = "JFROG_CLI_DEPENDENCIES_DIR"
 	FailNoOp                = "JFROG_CLI_FAIL_NO_OP"
 	SummaryOutputDirPathEnv = "JFROG_CLI_COMMAND_SUMMARY_OUTPUT_DIR"
-	CI                      = "CI"
-	ServerID                = "JFROG_CLI_SERVER_ID"
-	TransitiveDownload      = "JFROG_CLI_TRANSITIVE_DOWNLOAD"
+	// Comma-separated list of the command summary output formats: markdown (default), html and json.
+	SummaryOutputFormatEnv = "JFROG_CLI_COMMAND_SUMMARY_FORMAT"
+	// The maximum size in bytes of the Markdown summary of each command. Larger summaries are truncated, and 0 means no limit.
+	// If not set, the summaries are limited to 1MB only in GitHub Actions, which limits the size of job summaries.
+	SummaryMaxSizeEnv  = "JFROG_CLI_COMMAND_SUMMARY_MAX_SIZE"
+	CI                 = "CI"
+	ServerID           = "JFROG_CLI_SERVER_ID"
+	TransitiveDownload = "JFROG_CLI_TRANSITIVE_DOWNLOAD"
+	// The container manager used by the container commands e.g. podman.
+	ContainerManager = "JFROG_CLI_CONTAINER_MANAGER"
 	// Token provided by the OIDC provider, used to exchange for an access token.
 	//#nosec G101 // False positive: This is not a hardcoded credential.
 	OidcExchangeTokenId = "JFROG_CLI_OIDC_EXCHANGE_TOKEN_ID"
@@ -0,0 +1,388 @@
+package plugins
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/gofrog/crypto"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The lockfile, which pins the plugins versions of a project, is located at <project-dir>/.jfrog/plugins.lock
+	PluginsLockfileName     = "plugins.lock"
+	projectConfigDirName    = ".jfrog"
+	pluginsLockfileVersion  = 1
+	pluginExecutablePerm    = 0777
+	pluginsConfigFilePerm   = 0600
+	pluginsLockfileFilePerm = 0644
+)
+
+// A plugin installed at '.jfrog/plugins'.
+type InstalledPlugin struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// The URL the plugin was downloaded from.
+	SourceUrl string `json:"sourceUrl,omitempty"`
+	// The SHA256 of the plugin executable, which is verified when the plugin is loaded.
+	Sha256 string `json:"sha256"`
+	// The signature of the plugin executable, as published with the plugin.
+	Signature string `json:"signature,omitempty"`
+}
+
+// The plugins versions pinned by a project.
+type PluginsLockfile struct {
+	Version int                     `json:"version"`
+	Plugins map[string]PinnedPlugin `json:"plugins,omitempty"`
+}
+
+type PinnedPlugin struct {
+	Version   string `json:"version"`
+	Sha256    string `json:"sha256,omitempty"`
+	SourceUrl string `json:"sourceUrl,omitempty"`
+}
+
+// Returns the installed plugins, sorted by their names.
+func GetInstalledPlugins() (plugins []InstalledPlugin, err error) {
+	err = runWithPluginsLock(func() error {
+		pluginsConfig, err := readPluginsConfig()
+		if err != nil {
+			return err
+		}
+		for _, plugin := range pluginsConfig.Plugins {
+			plugins = append(plugins, *plugin)
+		}
+		return nil
+	})
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return
+}
+
+// Returns the installed plugin, or nil if the plugin isn't listed in the registry.
+func GetInstalledPlugin(pluginName string) (plugin *InstalledPlugin, err error) {
+	if err = validatePluginName(pluginName); err != nil {
+		return
+	}
+	err = runWithPluginsLock(func() error {
+		pluginsConfig, err := readPluginsConfig()
+		if err != nil {
+			return err
+		}
+		plugin = pluginsConfig.Plugins[pluginName]
+		return nil
+	})
+	return
+}
+
+// Install the plugin executable at '.jfrog/plugins/<plugin-name>/bin' and register it.
+// If the SHA256 of the plugin is provided, the executable is verified against it. Otherwise, it is calculated.
+// An installed plugin with the same name is replaced.
+func InstallPlugin(plugin InstalledPlugin, executablePath string) (*InstalledPlugin, error) {
+	if plugin.Name == "" || plugin.Version == "" {
+		return nil, errorutils.CheckErrorf("the name and version of the plugin are mandatory")
+	}
+	if err := validatePluginName(plugin.Name); err != nil {
+		return nil, err
+	}
+	sha256, err := getFileSha256(executablePath)
+	if err != nil {
+		return nil, err
+	}
+	if plugin.Sha256 != "" && plugin.Sha256 != sha256 {
+		return nil, errorutils.CheckErrorf("the SHA256 of the '%s' plugin executable is %s, but %s was expected. The executable may be corrupted", plugin.Name, sha256, plugin.Sha256)
+	}
+	plugin.Sha256 = sha256
+	// Make sure the plugins layout is up-to-date before adding the plugin.
+	if err = CheckPluginsVersionAndConvertIfNeeded(); err != nil {
+		return nil, err
+	}
+	err = runWithPluginsLock(func() error {
+		pluginsConfig, err := readPluginsConfig()
+		if err != nil {
+			return err
+		}
+		if err = copyPluginExecutable(plugin.Name, executablePath); err != nil {
+			return err
+		}
+		pluginsConfig.Plugins[plugin.Name] = &plugin
+		return savePluginsConfig(pluginsConfig)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Installed version %s of the '%s' plugin.", plugin.Version, plugin.Name))
+	return &plugin, nil
+}
+
+// Replace an installed plugin with another version. Returns the previously installed plugin.
+// If a lockfile is provided, the plugin can't be upgraded to a version other than the version pinned by the lockfile.
+func UpgradePlugin(plugin InstalledPlugin, executablePath string, lockfile *PluginsLockfile) (previous *InstalledPlugin, err error) {
+	previous, err = GetInstalledPlugin(plugin.Name)
+	if err != nil {
+		return
+	}
+	if previous == nil {
+		return nil, errorutils.CheckErrorf("the '%s' plugin is not installed", plugin.Name)
+	}
+	if lockfile != nil {
+		if pinned, ok := lockfile.Plugins[plugin.Name]; ok && pinned.Version != plugin.Version {
+			return nil, errorutils.CheckErrorf("the '%s' plugin is pinned to version %s by the project lockfile, and can't be upgraded to version %s", plugin.Name, pinned.Version, plugin.Version)
+		}
+	}
+	if previous.Version == plugin.Version && (plugin.Sha256 == "" || previous.Sha256 == plugin.Sha256) {
+		log.Info(fmt.Sprintf("Version %s of the '%s' plugin is already installed.", plugin.Version, plugin.Name))
+		return previous, nil
+	}
+	_, err = InstallPlugin(plugin, executablePath)
+	return
+}
+
+// Remove the plugin directory and its registration.
+func RemovePlugin(pluginName string) error {
+	if err := validatePluginName(pluginName); err != nil {
+		return err
+	}
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return err
+	}
+	err = runWithPluginsLock(func() error {
+		pluginsConfig, err := readPluginsConfig()
+		if err != nil {
+			return err
+		}
+		pluginDir := filepath.Join(pluginsDir, pluginName)
+		exists, err := fileutils.IsDirExists(pluginDir, false)
+		if err != nil {
+			return err
+		}
+		if _, registered := pluginsConfig.Plugins[pluginName]; !registered && !exists {
+			return errorutils.CheckErrorf("the '%s' plugin is not installed", pluginName)
+		}
+		if err = os.RemoveAll(pluginDir); err != nil {
+			return errorutils.CheckError(err)
+		}
+		delete(pluginsConfig.Plugins, pluginName)
+		return savePluginsConfig(pluginsConfig)
+	})
+	if err == nil {
+		log.Info(fmt.Sprintf("Removed the '%s' plugin.", pluginName))
+	}
+	return err
+}
+
+// Verify the integrity of the plugin executable before loading it, and return its path.
+// Plugins, which aren't listed in the registry because they were installed before it was introduced, are not verified.
+func VerifyPluginIntegrity(pluginName string) (executablePath string, err error) {
+	executablePath, err = getPluginExecutablePath(pluginName)
+	if err != nil {
+		return
+	}
+	plugin, err := GetInstalledPlugin(pluginName)
+	if err != nil {
+		return
+	}
+	if plugin == nil {
+		log.Debug(fmt.Sprintf("The '%s' plugin is not listed in the plugins registry. Skipping its integrity verification.", pluginName))
+		return
+	}
+	sha256, err := getFileSha256(executablePath)
+	if err != nil {
+		return
+	}
+	if sha256 != plugin.Sha256 {
+		return "", errorutils.CheckErrorf("the integrity verification of the '%s' plugin failed: the SHA256 of its executable is %s, but %s was expected. Please reinstall the plugin", pluginName, sha256, plugin.Sha256)
+	}
+	return
+}
+
+// Read the plugins lockfile of the project. Returns an empty lockfile if the project has no lockfile.
+func ReadPluginsLockfile(projectDir string) (*PluginsLockfile, error) {
+	lockfile := &PluginsLockfile{Version: pluginsLockfileVersion, Plugins: make(map[string]PinnedPlugin)}
+	content, err := os.ReadFile(getPluginsLockfilePath(projectDir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return lockfile, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the plugins lockfile of '%s': %s", projectDir, err.Error())
+	}
+	if lockfile.Version > pluginsLockfileVersion {
+		return nil, errorutils.CheckErrorf("the plugins lockfile of '%s' was created by a newer version of JFrog CLI (lockfile version %d). Please upgrade JFrog CLI", projectDir, lockfile.Version)
+	}
+	if lockfile.Plugins == nil {
+		lockfile.Plugins = make(map[string]PinnedPlugin)
+	}
+	return lockfile, nil
+}
+
+// Find the plugins lockfile of the project, which contains the working directory.
+// Returns an empty projectDir if the working directory isn't part of a project with a '.jfrog' directory.
+func FindPluginsLockfile() (projectDir string, lockfile *PluginsLockfile, err error) {
+	projectDir, exists, err := fileutils.FindUpstream(projectConfigDirName, fileutils.Dir)
+	if err != nil || !exists {
+		return "", nil, err
+	}
+	lockfile, err = ReadPluginsLockfile(projectDir)
+	return
+}
+
+func (lf *PluginsLockfile) Save(projectDir string) error {
+	lockfilePath := getPluginsLockfilePath(projectDir)
+	if err := fileutils.CreateDirIfNotExist(filepath.Dir(lockfilePath)); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(lf, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(lockfilePath, content, pluginsLockfileFilePerm))
+}
+
+// Pin the version and SHA256 of the plugin.
+func (lf *PluginsLockfile) Pin(plugin InstalledPlugin) *PluginsLockfile {
+	lf.Plugins[plugin.Name] = PinnedPlugin{Version: plugin.Version, Sha256: plugin.Sha256, SourceUrl: plugin.SourceUrl}
+	return lf
+}
+
+func (lf *PluginsLockfile) Unpin(pluginName string) *PluginsLockfile {
+	delete(lf.Plugins, pluginName)
+	return lf
+}
+
+// Verify that the pinned plugins are installed with their pinned versions and SHA256.
+func (lf *PluginsLockfile) Verify(installedPlugins []InstalledPlugin) error {
+	installed := make(map[string]InstalledPlugin)
+	for _, plugin := range installedPlugins {
+		installed[plugin.Name] = plugin
+	}
+	pluginNames := make([]string, 0, len(lf.Plugins))
+	for pluginName := range lf.Plugins {
+		pluginNames = append(pluginNames, pluginName)
+	}
+	sort.Strings(pluginNames)
+	var errs []error
+	for _, pluginName := range pluginNames {
+		pinned := lf.Plugins[pluginName]
+		plugin, ok := installed[pluginName]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("the '%s' plugin is pinned to version %s, but it is not installed", pluginName, pinned.Version))
+		case plugin.Version != pinned.Version:
+			errs = append(errs, fmt.Errorf("the '%s' plugin is pinned to version %s, but version %s is installed", pluginName, pinned.Version, plugin.Version))
+		case pinned.Sha256 != "" && plugin.Sha256 != pinned.Sha256:
+			errs = append(errs, fmt.Errorf("the SHA256 of the installed '%s' plugin doesn't match the pinned SHA256", pluginName))
+		}
+	}
+	if len(errs) > 0 {
+		return errorutils.CheckErrorf("the installed plugins don't match the project plugins lockfile:\n%s", errors.Join(errs...).Error())
+	}
+	return nil
+}
+
+func getPluginsLockfilePath(projectDir string) string {
+	return filepath.Join(projectDir, projectConfigDirName, PluginsLockfileName)
+}
+
+// Read the plugins config file. Must be called while holding the plugins lock.
+func readPluginsConfig() (*PluginsV1, error) {
+	content, err := getPluginsConfigFileContent()
+	if err != nil {
+		return nil, err
+	}
+	pluginsConfig := &PluginsV1{Version: coreutils.GetPluginsConfigVersion()}
+	if len(content) > 0 {
+		if err = json.Unmarshal(content, pluginsConfig); err != nil {
+			return nil, errorutils.CheckErrorf("failed to read the plugins config file: %s", err.Error())
+		}
+	}
+	if pluginsConfig.Plugins == nil {
+		pluginsConfig.Plugins = make(map[string]*InstalledPlugin)
+	}
+	return pluginsConfig, nil
+}
+
+// Save the plugins config file. Must be called while holding the plugins lock.
+func savePluginsConfig(pluginsConfig *PluginsV1) error {
+	pluginsFilePath, err := getPluginsFilePath()
+	if err != nil {
+		return err
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(pluginsFilePath)); err != nil {
+		return err
+	}
+	content, err := json.Marshal(pluginsConfig)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(pluginsFilePath, content, pluginsConfigFilePerm))
+}
+
+func getPluginExecutablePath(pluginName string) (string, error) {
+	if err := validatePluginName(pluginName); err != nil {
+		return "", err
+	}
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(pluginsDir, pluginName, coreutils.PluginsExecDirName, GetLocalPluginExecutableName(pluginName)), nil
+}
+
+// Copy the executable into the plugin directory. The executable is written to a temporary file first,
+// so that a failure doesn't leave a partially written executable.
+func copyPluginExecutable(pluginName, sourcePath string) (err error) {
+	targetPath, err := getPluginExecutablePath(pluginName)
+	if err != nil {
+		return
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(targetPath)); err != nil {
+		return
+	}
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(source.Close()))
+	}()
+	tempPath := targetPath + ".tmp"
+	target, err := os.OpenFile(tempPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, pluginExecutablePerm)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = io.Copy(target, source)
+	if err = errors.Join(err, target.Close()); err != nil {
+		return errorutils.CheckError(errors.Join(err, os.Remove(tempPath)))
+	}
+	return errorutils.CheckError(os.Rename(tempPath, targetPath))
+}
+
+// The plugin name is used as the name of the plugin directory, so it must not point outside the plugins directory.
+func validatePluginName(pluginName string) error {
+	if pluginName == "" || pluginName == "." || strings.Contains(pluginName, "..") || strings.ContainsAny(pluginName, `/\`) || filepath.Base(pluginName) != pluginName {
+		return errorutils.CheckErrorf("invalid plugin name '%s'. The plugin name must not be empty, or contain path separators or '..'", pluginName)
+	}
+	return nil
+}
+
+func getFileSha256(filePath string) (string, error) {
+	checksums, err := crypto.GetFileChecksums(filePath, crypto.SHA256)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return checksums[crypto.SHA256], nil
+}
@@ -0,0 +1,165 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testPluginName = "test-plugin"
+	// The SHA256 of "version 1"
+	testPluginV1Sha256 = "b19f8edae2ee6c225b7278b289c2823ab9accfa225c5d67c4bef270b88ea55f0"
+)
+
+func createTestExecutable(t *testing.T, content string) string {
+	executablePath := filepath.Join(t.TempDir(), GetLocalPluginExecutableName(testPluginName))
+	assert.NoError(t, os.WriteFile(executablePath, []byte(content), 0755))
+	return executablePath
+}
+
+func TestInstallAndRemovePlugin(t *testing.T) {
+	cleanUpTempEnv := createTempEnvForPluginsTests(t)
+	defer cleanUpTempEnv()
+
+	plugin, err := InstallPlugin(InstalledPlugin{Name: testPluginName, Version: "1.0.0", SourceUrl: "https://releases.jfrog.io/test-plugin", Signature: "sig"}, createTestExecutable(t, "version 1"))
+	assert.NoError(t, err)
+	assert.Len(t, plugin.Sha256, 64)
+
+	plugins, err := GetInstalledPlugins()
+	assert.NoError(t, err)
+	assert.Equal(t, []InstalledPlugin{*plugin}, plugins)
+	executablePath, err := VerifyPluginIntegrity(testPluginName)
+	assert.NoError(t, err)
+	content, err := os.ReadFile(executablePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "version 1", string(content))
+
+	// The plugins config keeps its layout version
+	pluginsFilePath, err := getPluginsFilePath()
+	assert.NoError(t, err)
+	pluginsConfig, err := readPluginsConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, coreutils.GetPluginsConfigVersion(), pluginsConfig.Version)
+	assert.FileExists(t, pluginsFilePath)
+
+	assert.NoError(t, RemovePlugin(testPluginName))
+	plugins, err = GetInstalledPlugins()
+	assert.NoError(t, err)
+	assert.Empty(t, plugins)
+	assert.NoFileExists(t, executablePath)
+	assert.ErrorContains(t, RemovePlugin(testPluginName), "is not installed")
+}
+
+func TestInstallPluginWithWrongSha256(t *testing.T) {
+	cleanUpTempEnv := createTempEnvForPluginsTests(t)
+	defer cleanUpTempEnv()
+
+	_, err := InstallPlugin(InstalledPlugin{Name: testPluginName, Version: "1.0.0", Sha256: testPluginV1Sha256}, createTestExecutable(t, "tampered"))
+	assert.ErrorContains(t, err, "may be corrupted")
+	plugins, err := GetInstalledPlugins()
+	assert.NoError(t, err)
+	assert.Empty(t, plugins)
+}
+
+func TestVerifyPluginIntegrity(t *testing.T) {
+	cleanUpTempEnv := createTempEnvForPluginsTests(t)
+	defer cleanUpTempEnv()
+
+	_, err := InstallPlugin(InstalledPlugin{Name: testPluginName, Version: "1.0.0"}, createTestExecutable(t, "version 1"))
+	assert.NoError(t, err)
+	executablePath, err := getPluginExecutablePath(testPluginName)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(executablePath, []byte("tampered"), 0755))
+	_, err = VerifyPluginIntegrity(testPluginName)
+	assert.ErrorContains(t, err, "integrity verification of the 'test-plugin' plugin failed")
+
+	// Plugins, which aren't listed in the registry, are not verified
+	_, err = VerifyPluginIntegrity("unlisted-plugin")
+	assert.NoError(t, err)
+}
+
+func TestUpgradePlugin(t *testing.T) {
+	cleanUpTempEnv := createTempEnvForPluginsTests(t)
+	defer cleanUpTempEnv()
+
+	_, err := UpgradePlugin(InstalledPlugin{Name: testPluginName, Version: "2.0.0"}, createTestExecutable(t, "version 2"), nil)
+	assert.ErrorContains(t, err, "is not installed")
+
+	installed, err := InstallPlugin(InstalledPlugin{Name: testPluginName, Version: "1.0.0"}, createTestExecutable(t, "version 1"))
+	assert.NoError(t, err)
+
+	// The lockfile pins version 1.0.0
+	lockfile := (&PluginsLockfile{Plugins: map[string]PinnedPlugin{}}).Pin(*installed)
+	_, err = UpgradePlugin(InstalledPlugin{Name: testPluginName, Version: "2.0.0"}, createTestExecutable(t, "version 2"), lockfile)
+	assert.ErrorContains(t, err, "is pinned to version 1.0.0")
+
+	previous, err := UpgradePlugin(InstalledPlugin{Name: testPluginName, Version: "2.0.0"}, createTestExecutable(t, "version 2"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, installed, previous)
+	upgraded, err := GetInstalledPlugin(testPluginName)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.0", upgraded.Version)
+	executablePath, err := VerifyPluginIntegrity(testPluginName)
+	assert.NoError(t, err)
+	content, err := os.ReadFile(executablePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "version 2", string(content))
+}
+
+func TestPluginsLockfile(t *testing.T) {
+	projectDir := t.TempDir()
+	lockfile, err := ReadPluginsLockfile(projectDir)
+	assert.NoError(t, err)
+	assert.Empty(t, lockfile.Plugins)
+
+	lockfile.Pin(InstalledPlugin{Name: "a", Version: "1.0.0", Sha256: "sha-a"}).Pin(InstalledPlugin{Name: "b", Version: "2.0.0"}).Pin(InstalledPlugin{Name: "c", Version: "1.0.0"})
+	assert.NoError(t, lockfile.Save(projectDir))
+	assert.FileExists(t, filepath.Join(projectDir, ".jfrog", PluginsLockfileName))
+	readLockfile, err := ReadPluginsLockfile(projectDir)
+	assert.NoError(t, err)
+	assert.Equal(t, lockfile, readLockfile)
+
+	err = readLockfile.Verify([]InstalledPlugin{{Name: "a", Version: "1.0.0", Sha256: "other"}, {Name: "b", Version: "2.1.0"}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the SHA256 of the installed 'a' plugin doesn't match the pinned SHA256")
+		assert.Contains(t, err.Error(), "the 'b' plugin is pinned to version 2.0.0, but version 2.1.0 is installed")
+		assert.Contains(t, err.Error(), "the 'c' plugin is pinned to version 1.0.0, but it is not installed")
+	}
+	readLockfile.Unpin("b").Unpin("c")
+	assert.NoError(t, readLockfile.Verify([]InstalledPlugin{{Name: "a", Version: "1.0.0", Sha256: "sha-a"}}))
+
+	// Lockfiles of newer versions are rejected
+	assert.NoError(t, os.WriteFile(filepath.Join(projectDir, ".jfrog", PluginsLockfileName), []byte(`{"version": 2}`), 0644))
+	_, err = ReadPluginsLockfile(projectDir)
+	assert.ErrorContains(t, err, "newer version of JFrog CLI")
+}
+
+func TestInvalidPluginNames(t *testing.T) {
+	cleanUpTempEnv := createTempEnvForPluginsTests(t)
+	defer cleanUpTempEnv()
+	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(jfrogHomeDir, 0755))
+	executablePath := createTestExecutable(t, "version 1")
+
+	for _, pluginName := range []string{"", ".", "..", "../x", "x/..", "a/b", `a\b`, "a..b"} {
+		t.Run(pluginName, func(t *testing.T) {
+			assert.ErrorContains(t, RemovePlugin(pluginName), "invalid plugin name")
+			_, err := InstallPlugin(InstalledPlugin{Name: pluginName, Version: "1.0.0"}, executablePath)
+			assert.Error(t, err)
+			_, err = UpgradePlugin(InstalledPlugin{Name: pluginName, Version: "1.0.0"}, executablePath, nil)
+			assert.ErrorContains(t, err, "invalid plugin name")
+			_, err = GetInstalledPlugin(pluginName)
+			assert.ErrorContains(t, err, "invalid plugin name")
+			_, err = VerifyPluginIntegrity(pluginName)
+			assert.ErrorContains(t, err, "invalid plugin name")
+		})
+	}
+	// The JFrog home directory and the executable outside the plugins directory are untouched
+	assert.DirExists(t, jfrogHomeDir)
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(jfrogHomeDir), "x"))
+}
@@ -25,6 +25,8 @@ func init() {
 
 type PluginsV1 struct {
 	Version int `json:"version,omitempty"`
+	// The installed plugins by their names. Plugins installed before the registry was introduced are not listed.
+	Plugins map[string]*InstalledPlugin `json:"plugins,omitempty"`
 }
 
 // CheckPluginsVersionAndConvertIfNeeded In case the latest plugin's layout version isn't match to the local plugins hierarchy at '.jfrog/plugins' -
@@ -49,6 +51,24 @@ func readPluginsConfigAndConvertV0tToV1IfNeeded() (err error) {
 	if err != nil || len(content) != 0 {
 		return
 	}
+	return runWithPluginsLock(func() error {
+		// The reason behind reading the config again is that it's possible that another thread or process already changed the plugins file,
+		// So we read again inside that locked section to indicate that we indeed need to convert the plugins' layout.
+		content, err := getPluginsConfigFileContent()
+		if err != nil {
+			return err
+		}
+		if len(content) == 0 {
+			// No plugins.yaml file was found. This means that we are in v0.
+			// Convert plugins layout to the latest version.
+			_, err = convertPluginsV0ToV1()
+		}
+		return err
+	})
+}
+
+// Run an operation, which reads or changes the local plugins at '.jfrog/plugins', while holding the plugins lock.
+func runWithPluginsLock(operation func() error) (err error) {
 	// Locking mechanism - two threads in the same process.
 	mutex.Lock()
 	defer mutex.Unlock()
@@ -67,18 +87,7 @@ func readPluginsConfigAndConvertV0tToV1IfNeeded() (err error) {
 	if err != nil {
 		return
 	}
-	// The reason behind reading the config again is that it's possible that another thread or process already changed the plugins file,
-	// So we read again inside that locked section to indicate that we indeed need to convert the plugins' layout.
-	content, err = getPluginsConfigFileContent()
-	if err != nil {
-		return
-	}
-	if len(content) == 0 {
-		// No plugins.yaml file was found. This means that we are in v0.
-		// Convert plugins layout to the latest version.
-		_, err = convertPluginsV0ToV1()
-	}
-	return
+	return operation()
 }
 
 func getPluginsConfigFileContent() (content []byte, err error) {